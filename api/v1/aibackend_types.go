@@ -0,0 +1,57 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AIBackendSpec 描述了一个可被 PodDiagnosisReconciler 选用的 LLM 后端连接方式。
+// 通过在命名空间内创建不同的 AIBackend，运维可以按命名空间切换模型供应商而无需重启 operator。
+type AIBackendSpec struct {
+	// Backend 后端类型：openai、azure-openai、anthropic、ollama、llamacpp
+	Backend string `json:"backend"`
+	// BaseURL 服务地址，例如 https://api.openai.com/v1 或 http://ollama.default:11434
+	BaseURL string `json:"baseURL"`
+	// Model 模型名称或 Azure 部署名称
+	Model string `json:"model,omitempty"`
+	// AzureAPIVersion 仅 backend 为 azure-openai 时使用
+	AzureAPIVersion string `json:"azureAPIVersion,omitempty"`
+	// SecretRef 指向包含 apiKey 字段的 Secret；本地后端（ollama/llamacpp）通常无需设置
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// Default 为 true 时，本 AIBackend 作为所在命名空间内未显式指定 aiBackendRef 的 PodDiagnosis 的默认后端
+	Default bool `json:"default,omitempty"`
+}
+
+// AIBackendStatus 记录该后端配置是否通过校验
+type AIBackendStatus struct {
+	// Phase 当前状态：Ready, Invalid
+	Phase string `json:"phase,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Backend",type=string,JSONPath=`.spec.backend`
+//+kubebuilder:printcolumn:name="Default",type=boolean,JSONPath=`.spec.default`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// AIBackend 是 aibackends API 的 Schema，用于声明式配置 LLMProvider 的连接信息
+type AIBackend struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AIBackendSpec   `json:"spec,omitempty"`
+	Status AIBackendStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AIBackendList 包含了一组 AIBackend
+type AIBackendList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AIBackend `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AIBackend{}, &AIBackendList{})
+}
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterTargetSpec 描述如何连接到一个被纳管的远程集群。三种接入方式互斥，
+// pkg/clusters 按以下优先级选择：KubeconfigSecretRef > ServiceAccountTokenSecretRef > AgentEndpoint。
+type ClusterTargetSpec struct {
+	// KubeconfigSecretRef 指向包含内联 kubeconfig 的 Secret（数据键固定为 "kubeconfig"）
+	KubeconfigSecretRef *corev1.LocalObjectReference `json:"kubeconfigSecretRef,omitempty"`
+	// ServiceAccountTokenSecretRef 指向导入的目标集群 ServiceAccount Token Secret（数据键 "token"，可选 "ca.crt"），需配合 Server 使用
+	ServiceAccountTokenSecretRef *corev1.LocalObjectReference `json:"serviceAccountTokenSecretRef,omitempty"`
+	// Server 目标集群 API Server 地址，搭配 ServiceAccountTokenSecretRef 使用
+	Server string `json:"server,omitempty"`
+	// AgentEndpoint 指向部署在目标集群内、反向代理 API 请求的 in-cluster agent 地址，
+	// 适用于目标集群 API Server 不可直接访问的场景
+	AgentEndpoint string `json:"agentEndpoint,omitempty"`
+}
+
+// ClusterTargetStatus 记录该集群接入配置最近一次的校验结果
+type ClusterTargetStatus struct {
+	// Phase 当前状态：Ready, Invalid
+	Phase string `json:"phase,omitempty"`
+	// LastVerifiedTime 最近一次成功建立客户端连接的时间
+	LastVerifiedTime *metav1.Time `json:"lastVerifiedTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterTarget 是 clustertargets API 的 Schema，声明一个可被诊断的外部集群
+type ClusterTarget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterTargetSpec   `json:"spec,omitempty"`
+	Status ClusterTargetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterTargetList 包含了一组 ClusterTarget
+type ClusterTargetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterTarget `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterTarget{}, &ClusterTargetList{})
+}
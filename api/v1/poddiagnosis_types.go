@@ -14,6 +14,68 @@ type PodDiagnosisSpec struct {
 	TriggerReason string `json:"triggerReason,omitempty"`
 	// 需要向前获取的日志行数，默认可设为 100
 	TailLines int32 `json:"tailLines,omitempty"`
+	// AIBackendRef 指定本次诊断使用的 AIBackend 名称（需与本资源同命名空间）；
+	// 留空时使用该命名空间下 spec.default=true 的 AIBackend
+	AIBackendRef string `json:"aiBackendRef,omitempty"`
+	// ClusterRef 指定目标 Pod 所在的 ClusterTarget 名称（需与本资源同命名空间）；
+	// 留空时表示目标 Pod 位于 operator 所在的本地集群
+	ClusterRef string `json:"clusterRef,omitempty"`
+	// Approved 为 true 时表示运维人员已审核通过本次诊断给出的修复动作，
+	// remediation_controller 才会对 RequireApproval 模式下的动作放行执行；
+	// 该字段设计为可由 kubectl edit/patch 直接修改
+	Approved bool `json:"approved,omitempty"`
+	// LogDigest 配置日志预处理流水线（切分、去重、Embedding 检索）的行为；
+	// 留空时使用各子字段的零值默认行为
+	LogDigest *LogDigestSpec `json:"logDigest,omitempty"`
+}
+
+// LogDigestSpec 控制 getPodLogs 抓取到的原始日志在喂给 AI 前如何被切分、去重与筛选
+type LogDigestSpec struct {
+	// MaxTokens 粗略限制预处理后文本的 Token 数量（按 4 字符约等于 1 Token 估算），<=0 表示不限制
+	MaxTokens int32 `json:"maxTokens,omitempty"`
+	// KeepTailBytes 保留原始日志末尾的这么多字节并拼接在摘要之后；设置了 MaxTokens 时，
+	// 尾部只能占用错误上下文块之后剩余的预算，超出部分会被截掉
+	KeepTailBytes int32 `json:"keepTailBytes,omitempty"`
+	// EmbeddingModel 非空时启用基于 Embedding 的 Top-K 检索，取值为所配置 Embedding 服务的模型名称
+	EmbeddingModel string `json:"embeddingModel,omitempty"`
+}
+
+// TokenUsage 记录一次 AI 调用消耗的 Token 数，用于成本审计
+type TokenUsage struct {
+	// PromptTokens 输入侧消耗的 Token 数
+	PromptTokens int32 `json:"promptTokens,omitempty"`
+	// CompletionTokens 输出侧消耗的 Token 数
+	CompletionTokens int32 `json:"completionTokens,omitempty"`
+	// TotalTokens 本次调用消耗的 Token 总数
+	TotalTokens int32 `json:"totalTokens,omitempty"`
+}
+
+// EventRef 记录促成一次诊断的 core/v1 Event，供 AI Prompt 引用及后续审计
+type EventRef struct {
+	// Reason 事件原因，例如 "BackOff"、"FailedScheduling"
+	Reason string `json:"reason"`
+	// Message 事件详情
+	Message string `json:"message,omitempty"`
+	// Count 该事件在关联窗口内的累计出现次数
+	Count int32 `json:"count,omitempty"`
+	// LastTimestamp 最近一次出现的时间
+	LastTimestamp *metav1.Time `json:"lastTimestamp,omitempty"`
+}
+
+// RemediationAction 是 AI 诊断给出的一项建议修复动作及其执行结果
+type RemediationAction struct {
+	// Kind 动作类型：RestartPod、IncreaseMemoryLimit、PatchImage、DeletePVC、CordonNode
+	Kind string `json:"kind"`
+	// Params 执行该动作所需的补充参数，例如 IncreaseMemoryLimit 的目标内存值、PatchImage 的目标镜像
+	Params map[string]string `json:"params,omitempty"`
+	// Reason 该动作针对的问题简述，便于审批人判断是否批准
+	Reason string `json:"reason,omitempty"`
+	// Phase 动作的执行阶段：Pending, Skipped, Succeeded, Failed
+	Phase string `json:"phase,omitempty"`
+	// Result 记录动作实际执行后的结果说明（成功详情或失败原因）
+	Result string `json:"result,omitempty"`
+	// ExecutedAt 动作被 remediation_controller 处理的时间
+	ExecutedAt *metav1.Time `json:"executedAt,omitempty"`
 }
 
 // PodDiagnosisStatus 定义了诊断任务的实际状态和 AI 诊断结果
@@ -26,6 +88,27 @@ type PodDiagnosisStatus struct {
 	Suggestion string `json:"suggestion,omitempty"`
 	// 诊断完成的时间戳
 	DiagnosisTime *metav1.Time `json:"diagnosisTime,omitempty"`
+	// Evidence 记录由 EventWatcher 关联到本次诊断的告警事件，由事件驱动的触发路径填充
+	Evidence []EventRef `json:"evidence,omitempty"`
+	// TokenUsage 记录本次 AI 调用消耗的 Token
+	TokenUsage *TokenUsage `json:"tokenUsage,omitempty"`
+	// Context 持久化一份脱敏后的诊断上下文（事件、历史日志、资源配额、节点状况、发布历史），
+	// 供事后审计排查 AI 当时究竟看到了哪些信息
+	Context string `json:"context,omitempty"`
+	// Actions 记录本次诊断建议的自动修复动作及其执行结果，由 remediation_controller 负责回填
+	Actions []RemediationAction `json:"actions,omitempty"`
+	// LogStats 记录本次诊断中日志预处理流水线的处理情况，供观测原始日志被压缩的比例
+	LogStats *LogStats `json:"logStats,omitempty"`
+}
+
+// LogStats 汇总一次日志预处理流水线的处理结果
+type LogStats struct {
+	// RawBytes 预处理前的原始日志字节数
+	RawBytes int32 `json:"rawBytes,omitempty"`
+	// DigestedBytes 预处理后、实际拼入 Prompt 的文本字节数
+	DigestedBytes int32 `json:"digestedBytes,omitempty"`
+	// UniqueRecords 近似去重后剩余的逻辑记录条数
+	UniqueRecords int32 `json:"uniqueRecords,omitempty"`
 }
 
 //+kubebuilder:object:root=true
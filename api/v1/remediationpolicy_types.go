@@ -0,0 +1,62 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemediationMode 决定 remediation_controller 对某个命名空间内建议动作的处理方式
+// +kubebuilder:validation:Enum=DryRun;RequireApproval;Auto
+type RemediationMode string
+
+const (
+	// RemediationModeDryRun 只记录建议的动作，不做任何实际变更
+	RemediationModeDryRun RemediationMode = "DryRun"
+	// RemediationModeRequireApproval 需等待 PodDiagnosis.Spec.Approved 被置为 true 后才会执行
+	RemediationModeRequireApproval RemediationMode = "RequireApproval"
+	// RemediationModeAuto 一经诊断产出即自动执行，无需人工审批
+	RemediationModeAuto RemediationMode = "Auto"
+)
+
+// RemediationPolicySpec 声明某个命名空间下允许自动修复的动作种类及执行模式
+type RemediationPolicySpec struct {
+	// Namespace 本策略生效的命名空间；remediation_controller 按 PodDiagnosis 所在命名空间匹配同名策略
+	Namespace string `json:"namespace"`
+	// AllowedActions 本命名空间允许执行的动作类型，留空表示不允许执行任何动作（仅 DryRun 记录）
+	AllowedActions []string `json:"allowedActions,omitempty"`
+	// Mode 执行模式：DryRun、RequireApproval 或 Auto，默认为 DryRun
+	Mode RemediationMode `json:"mode,omitempty"`
+}
+
+// RemediationPolicyStatus 记录该策略最近一次被引用执行的情况
+type RemediationPolicyStatus struct {
+	// LastAppliedTime 最近一次有动作依据本策略被处理的时间
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.namespace`
+//+kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.mode`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// RemediationPolicy 是 remediationpolicies API 的 Schema，用于约束 AI 自动修复动作的放行范围
+type RemediationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemediationPolicySpec   `json:"spec,omitempty"`
+	Status RemediationPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RemediationPolicyList 包含了一组 RemediationPolicy
+type RemediationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemediationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RemediationPolicy{}, &RemediationPolicyList{})
+}
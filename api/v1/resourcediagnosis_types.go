@@ -0,0 +1,69 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TargetRef 唯一标识一个被诊断的 Kubernetes 资源，取代了 PodDiagnosis 中
+// 仅针对 Pod 的 PodName/Namespace 字段，使诊断能力可以推广到任意资源类型
+type TargetRef struct {
+	// Kind 目标资源的类型，例如 "Deployment"、"PersistentVolumeClaim"，需与已注册的 Analyzer.Kind() 一致
+	Kind string `json:"kind"`
+	// APIVersion 目标资源所属的 API 版本，例如 "apps/v1"
+	APIVersion string `json:"apiVersion"`
+	// Name 目标资源名称
+	Name string `json:"name"`
+	// Namespace 目标资源所在命名空间，集群级资源（如 Node）可为空
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ResourceDiagnosisSpec 定义了诊断任务的期望状态和目标
+type ResourceDiagnosisSpec struct {
+	// TargetRef 指向被诊断的资源
+	TargetRef TargetRef `json:"targetRef"`
+	// 触发诊断的原因，由分析器发现的 Finding 汇总而成
+	TriggerReason string `json:"triggerReason,omitempty"`
+	// 需要向前获取的日志行数，仅对支持日志的资源类型（如 Pod）有效
+	TailLines int32 `json:"tailLines,omitempty"`
+}
+
+// ResourceDiagnosisStatus 定义了诊断任务的实际状态和 AI 诊断结果
+type ResourceDiagnosisStatus struct {
+	// 当前诊断阶段：Pending, Diagnosing, Completed, Failed
+	Phase string `json:"phase,omitempty"`
+	// AI 总结的根本原因 (Root Cause)
+	RootCause string `json:"rootCause,omitempty"`
+	// AI 给出的修复建议 (Suggestion)
+	Suggestion string `json:"suggestion,omitempty"`
+	// 诊断完成的时间戳
+	DiagnosisTime *metav1.Time `json:"diagnosisTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.targetRef.kind`
+//+kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.targetRef.name`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ResourceDiagnosis 是 resourcediagnoses API 的 Schema，泛化自 PodDiagnosis
+type ResourceDiagnosis struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ResourceDiagnosisSpec   `json:"spec,omitempty"`
+	Status ResourceDiagnosisStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ResourceDiagnosisList 包含了一组 ResourceDiagnosis
+type ResourceDiagnosisList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceDiagnosis `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ResourceDiagnosis{}, &ResourceDiagnosisList{})
+}
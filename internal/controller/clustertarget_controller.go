@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	diagnosticv1 "github.com/sxslzs/ai-diagnostic-operator/api/v1"
+	"github.com/sxslzs/ai-diagnostic-operator/pkg/clusters"
+)
+
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=clustertargets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=clustertargets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// ClusterTargetReconciler 让 ClusterRegistry 的缓存跟随 ClusterTarget 及其引用的
+// kubeconfig/token Secret 更新而失效，并把最近一次校验结果写入 ClusterTargetStatus，
+// 否则密钥轮换后缓存的客户端会永久使用旧凭据，直到 operator 重启。
+type ClusterTargetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// ClusterRegistry 持有需要在凭据变化时失效的客户端缓存
+	ClusterRegistry *clusters.Registry
+}
+
+// Reconcile 使该 ClusterTarget 在 ClusterRegistry 中的缓存失效，并尝试重新建立一次客户端
+// 连接以刷新 Status.Phase/LastVerifiedTime
+func (r *ClusterTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var target diagnosticv1.ClusterTarget
+	if err := r.Get(ctx, req.NamespacedName, &target); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if r.ClusterRegistry != nil {
+		r.ClusterRegistry.Invalidate(target.Namespace, target.Name)
+	}
+
+	base := target.DeepCopy()
+	if r.ClusterRegistry == nil {
+		target.Status.Phase = "Invalid"
+	} else if _, err := r.ClusterRegistry.Get(ctx, target.Namespace, target.Name); err != nil {
+		logger.Error(err, "校验 ClusterTarget 客户端失败", "clusterTarget", target.Name)
+		target.Status.Phase = "Invalid"
+	} else {
+		now := metav1.Time{Time: time.Now()}
+		target.Status.Phase = "Ready"
+		target.Status.LastVerifiedTime = &now
+	}
+
+	if err := r.Status().Patch(ctx, &target, client.MergeFrom(base)); err != nil {
+		logger.Error(err, "回写 ClusterTarget.Status 失败", "clusterTarget", target.Name)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// secretRefName 返回 ClusterTarget 引用的 Secret 名称；未引用任何 Secret（如 AgentEndpoint 接入）时返回空
+func secretRefName(target *diagnosticv1.ClusterTarget) string {
+	switch {
+	case target.Spec.KubeconfigSecretRef != nil:
+		return target.Spec.KubeconfigSecretRef.Name
+	case target.Spec.ServiceAccountTokenSecretRef != nil:
+		return target.Spec.ServiceAccountTokenSecretRef.Name
+	default:
+		return ""
+	}
+}
+
+// clusterTargetsForSecret 反查同一命名空间下引用了该 Secret 的所有 ClusterTarget，
+// 用于把 Secret 更新事件映射为对应 ClusterTarget 的 Reconcile 请求
+func (r *ClusterTargetReconciler) clusterTargetsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	var list diagnosticv1.ClusterTargetList
+	if err := r.List(ctx, &list, client.InNamespace(secret.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "查询 ClusterTarget 列表失败")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		target := &list.Items[i]
+		if secretRefName(target) == secret.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(target),
+			})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterTargetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&diagnosticv1.ClusterTarget{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return r.clusterTargetsForSecret(ctx, obj)
+			}),
+		).
+		Named("clustertarget").
+		Complete(r)
+}
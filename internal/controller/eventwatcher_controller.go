@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	diagnosticv1 "github.com/sxslzs/ai-diagnostic-operator/api/v1"
+)
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=poddiagnoses,verbs=create;get;list;update;patch
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=poddiagnoses/status,verbs=update;patch
+
+var (
+	eventCorrelationWindow = flag.Duration("event-correlation-window", 10*time.Minute,
+		"同一 involvedObject 在该窗口内只触发一次事件驱动的诊断")
+	eventSeverityFilter = flag.String("event-severity-filter", string(corev1.EventTypeWarning),
+		"参与关联的事件类型，逗号分隔，默认仅 Warning")
+	eventEvidenceBufferSize = flag.Int("event-evidence-buffer-size", 20,
+		"每个 involvedObject 保留的最近事件条数（环形缓冲区大小）")
+)
+
+// correlatedReasons 是会触发诊断的 Warning 事件 Reason 白名单，
+// 覆盖短生命周期、两次 Reconcile 之间容易被错过的瞬时故障
+var correlatedReasons = map[string]bool{
+	"BackOff":          true,
+	"Failed":           true,
+	"FailedScheduling": true,
+	"OOMKilling":       true,
+	"Unhealthy":        true,
+	"NodeNotReady":     true,
+}
+
+// eventRingBuffer 是单个 involvedObject 的有界事件历史，用于填充 Status.Evidence
+type eventRingBuffer struct {
+	items []diagnosticv1.EventRef
+	max   int
+}
+
+func (b *eventRingBuffer) add(ref diagnosticv1.EventRef) {
+	b.items = append(b.items, ref)
+	if len(b.items) > b.max {
+		b.items = b.items[len(b.items)-b.max:]
+	}
+}
+
+// EventWatcher 通过 cache.SharedIndexInformer 监听 core/v1 Event，
+// 将 Warning 事件按 (namespace, involvedObject) 去重关联后触发 PodDiagnosis，
+// 弥补 PodWatcherReconciler 只在 Pod create/update 时才能感知故障的盲区。
+type EventWatcher struct {
+	client.Client
+	Clientset *kubernetes.Clientset
+
+	// CorrelationWindow 同一 involvedObject 的去重窗口，默认取自 --event-correlation-window
+	CorrelationWindow time.Duration
+	// SeverityFilter 参与关联的事件类型集合，默认取自 --event-severity-filter
+	SeverityFilter map[string]bool
+	// bufferSize 每个 involvedObject 的环形缓冲区大小
+	bufferSize int
+
+	mu          sync.Mutex
+	buffers     map[string]*eventRingBuffer
+	lastTrigger map[string]time.Time
+}
+
+// NewEventWatcher 构造 EventWatcher，使用包级 flag 的默认值
+func NewEventWatcher(c client.Client, clientset *kubernetes.Clientset) *EventWatcher {
+	severities := map[string]bool{}
+	for _, s := range strings.Split(*eventSeverityFilter, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			severities[s] = true
+		}
+	}
+	return &EventWatcher{
+		Client:            c,
+		Clientset:         clientset,
+		CorrelationWindow: *eventCorrelationWindow,
+		SeverityFilter:    severities,
+		bufferSize:        *eventEvidenceBufferSize,
+		buffers:           make(map[string]*eventRingBuffer),
+		lastTrigger:       make(map[string]time.Time),
+	}
+}
+
+// Start 实现 manager.Runnable，由 main.go 通过 mgr.Add(eventWatcher) 注册
+func (w *EventWatcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	informer := cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return w.Clientset.CoreV1().Events("").List(ctx, options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return w.Clientset.CoreV1().Events("").Watch(ctx, options)
+			},
+		},
+		&corev1.Event{},
+		0,
+		cache.Indexers{},
+	)
+
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { w.handleEvent(ctx, obj) },
+		UpdateFunc: func(_, newObj interface{}) { w.handleEvent(ctx, newObj) },
+	}); err != nil {
+		return fmt.Errorf("注册 Event Informer 处理器失败: %v", err)
+	}
+
+	logger.Info("EventWatcher 已启动，开始监听 core/v1 Events", "correlationWindow", w.CorrelationWindow)
+	informer.Run(ctx.Done())
+	return nil
+}
+
+func (w *EventWatcher) handleEvent(ctx context.Context, obj interface{}) {
+	evt, ok := obj.(*corev1.Event)
+	if !ok {
+		return
+	}
+	if len(w.SeverityFilter) > 0 && !w.SeverityFilter[evt.Type] {
+		return
+	}
+	if !correlatedReasons[evt.Reason] {
+		return
+	}
+
+	switch evt.InvolvedObject.Kind {
+	case "Pod":
+		w.correlatePod(ctx, evt, evt.InvolvedObject.Namespace, evt.InvolvedObject.Name)
+	case "Node":
+		// NodeNotReady/OOMKilling 等 Reason 由 kubelet/node-lifecycle-controller 打在 Node
+		// 对象上而非 Pod 上，需要反查该节点上的 Pod 才能落到 PodDiagnosis 的诊断对象上
+		pods, err := w.podsOnNode(ctx, evt.InvolvedObject.Name)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "查询节点上的 Pod 失败", "node", evt.InvolvedObject.Name)
+			return
+		}
+		for _, pod := range pods {
+			w.correlatePod(ctx, evt, pod.Namespace, pod.Name)
+		}
+	}
+}
+
+// podsOnNode 列出调度在指定节点且仍在运行的 Pod，用于把 Node 级别的事件下钻到具体 Pod
+func (w *EventWatcher) podsOnNode(ctx context.Context, nodeName string) ([]corev1.Pod, error) {
+	list, err := w.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s,status.phase=Running", nodeName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// correlatePod 把一条已通过严重级别/Reason 过滤的事件关联到 (namespace, podName)，
+// 维护其环形缓冲区与去重窗口，并在未处于去重窗口内时创建 PodDiagnosis
+func (w *EventWatcher) correlatePod(ctx context.Context, evt *corev1.Event, namespace, podName string) {
+	logger := log.FromContext(ctx)
+	key := fmt.Sprintf("%s/%s", namespace, podName)
+
+	w.mu.Lock()
+	buf, ok := w.buffers[key]
+	if !ok {
+		buf = &eventRingBuffer{max: w.bufferSize}
+		w.buffers[key] = buf
+	}
+	buf.add(diagnosticv1.EventRef{
+		Reason:        evt.Reason,
+		Message:       evt.Message,
+		Count:         evt.Count,
+		LastTimestamp: &metav1.Time{Time: evt.LastTimestamp.Time},
+	})
+	evidence := append([]diagnosticv1.EventRef(nil), buf.items...)
+
+	last, seen := w.lastTrigger[key]
+	if seen && time.Since(last) < w.CorrelationWindow {
+		w.mu.Unlock()
+		return
+	}
+	w.lastTrigger[key] = time.Now()
+	w.mu.Unlock()
+
+	diagnosis := &diagnosticv1.PodDiagnosis{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-event-diagnosis-", podName),
+			Namespace:    namespace,
+			Labels: map[string]string{
+				"diagnosed-pod": podName,
+				"created-by":    "event-watcher",
+			},
+		},
+		Spec: diagnosticv1.PodDiagnosisSpec{
+			PodName:       podName,
+			Namespace:     namespace,
+			TriggerReason: fmt.Sprintf("Warning event correlated: %s - %s", evt.Reason, evt.Message),
+			TailLines:     100,
+		},
+	}
+
+	if err := w.Create(ctx, diagnosis); err != nil {
+		logger.Error(err, "无法通过事件关联创建 PodDiagnosis", "pod", podName)
+		return
+	}
+
+	base := diagnosis.DeepCopy()
+	diagnosis.Status.Evidence = evidence
+	if err := w.Status().Patch(ctx, diagnosis, client.MergeFrom(base)); err != nil {
+		logger.Error(err, "写入 Status.Evidence 失败", "diagnosis", diagnosis.Name)
+	}
+
+	logger.Info("成功通过事件关联创建诊断任务", "pod", podName, "diagnosis", diagnosis.Name, "reason", evt.Reason)
+}
@@ -1,12 +1,9 @@
 package controller
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -22,14 +19,27 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	diagnosticv1 "github.com/sxslzs/ai-diagnostic-operator/api/v1"
+	"github.com/sxslzs/ai-diagnostic-operator/pkg/clusters"
+	"github.com/sxslzs/ai-diagnostic-operator/pkg/diagctx"
+	"github.com/sxslzs/ai-diagnostic-operator/pkg/llm"
+	"github.com/sxslzs/ai-diagnostic-operator/pkg/logdigest"
 )
 
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
-// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;list
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=replicasets;statefulsets,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get
 // +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=poddiagnoses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=poddiagnoses/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=poddiagnoses/finalizers,verbs=update
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=aibackends,verbs=get;list;watch
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=clustertargets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// diagEventLimit 限制单次诊断纳入补充上下文的历史事件条数
+const diagEventLimit = 10
 
 // PodDiagnosisReconciler reconciles a PodDiagnosis object
 type PodDiagnosisReconciler struct {
@@ -37,40 +47,37 @@ type PodDiagnosisReconciler struct {
 	Scheme    *runtime.Scheme
 	Clientset *kubernetes.Clientset
 	Recorder  record.EventRecorder
+	// ClusterRegistry 按 spec.clusterRef 解析出目标集群的客户端；未设置时所有诊断都视为本地集群
+	ClusterRegistry *clusters.Registry
 }
 
-// 定义API交互的数据结构
-type AIRequest struct {
-	Model          string          `json:"model"`
-	Messages       []Message       `json:"messages"`
-	Temperature    float32         `json:"temperature"`
-	ResponseFormat *ResponseFormat `json:"responseFormat"`
-}
-type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
-}
-type ResponseFormat struct {
-	Type string `json:"type"`
-}
-type AIResponse struct {
-	Choices []struct {
-		Message struct {
-			Content string `json:"content"`
-		} `json:"message"`
-	} `json:"choices"`
-}
-type DiagnosisResult struct {
-	RootCause  string `json:"rootCause"`
-	Suggestion string `json:"suggestion"`
+// resolveTargetClients 根据 spec.clusterRef 决定访问目标 Pod 应使用哪一套客户端：
+// 留空时复用 operator 所在本地集群的 Client/Clientset，否则从 ClusterRegistry 懒加载
+func (r *PodDiagnosisReconciler) resolveTargetClients(ctx context.Context, diagnosis *diagnosticv1.PodDiagnosis) (client.Client, *kubernetes.Clientset, error) {
+	if diagnosis.Spec.ClusterRef == "" {
+		return r.Client, r.Clientset, nil
+	}
+	if r.ClusterRegistry == nil {
+		return nil, nil, fmt.Errorf("未配置 ClusterRegistry，无法解析 clusterRef %q", diagnosis.Spec.ClusterRef)
+	}
+	cluster, err := r.ClusterRegistry.Get(ctx, diagnosis.Namespace, diagnosis.Spec.ClusterRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cluster.Client, cluster.Clientset, nil
 }
 
-func buildPrompt(podName, triggerReason, logs string) []Message {
+func buildPrompt(podName, triggerReason, logs, diagContext string) []llm.Message {
 	systemPrompt := `你是一个资深的云原生架构师与 Kubernetes SRE 专家。
-你的任务是根据提供的 Pod 故障信息和日志，分析崩溃的根本原因，并给出可执行的修复建议。
-请严格按照 JSON 格式输出，务必包含以下两个字段：
+你的任务是根据提供的 Pod 故障信息、日志及补充上下文，分析崩溃的根本原因，并给出可执行的修复建议。
+请严格按照 JSON 格式输出，务必包含以下字段：
 1. "rootCause": 用一两句话简明扼要地概括根本原因。
 2. "suggestion": 给出具体的排查或修复指令（如修改内存限制、检查配置字典等）。
+3. "actions": 可选，若存在可自动化执行的修复动作则以数组形式给出，每项包含：
+   - "kind": 动作类型，只能是 RestartPod、IncreaseMemoryLimit、PatchImage、DeletePVC、CordonNode 之一；
+   - "params": 执行该动作所需的参数（例如 IncreaseMemoryLimit 的 "memory" 目标值、PatchImage 的 "image"）；
+   - "reason": 该动作针对的问题简述。
+   不确定是否应该自动修复时，将 "actions" 留空，不要臆造。
 不要输出任何 Markdown 标记符或其他多余的解释性文字。`
 
 	userPrompt := fmt.Sprintf(`
@@ -79,73 +86,103 @@ func buildPrompt(podName, triggerReason, logs string) []Message {
 【尾部日志 (Stdout/Stderr)】:
 ---
 %s
----`, podName, triggerReason, logs)
+---
+【补充上下文 (历史事件/上次容器日志/资源配额/节点状况/发布历史)】:
+%s`, podName, triggerReason, logs, diagContext)
 
-	return []Message{
+	return []llm.Message{
 		{Role: "system", Content: systemPrompt},
 		{Role: "user", Content: userPrompt},
 	}
 }
 
-// callAIEndpoint 请求大模型并返回解析后的诊断结果
-func callAIEndpoint(ctx context.Context, podName, triggerReason, logs string) (*DiagnosisResult, error) {
-	apiKey := os.Getenv("AI_API_KEY")
-	apiURL := os.Getenv("AI_API_URL")
-	modelName := os.Getenv("AI_MODEL")
-
-	if apiKey == "" || apiURL == "" {
-		return nil, fmt.Errorf("AI_API_KEY 或 AI_API_URL 未配置")
+// resolveProvider 根据命名空间及 aiBackendRef 选出对应的 AIBackend，读取其关联 Secret 中的
+// apiKey 后构建出具体的 llm.Provider。未找到任何可用 AIBackend 时，回退到
+// AI_API_KEY/AI_API_URL/AI_MODEL 环境变量，兼容升级前的部署方式。PodDiagnosisReconciler 和
+// ResourceDiagnosisReconciler 共用同一套 AI 后端解析逻辑。
+func resolveProvider(ctx context.Context, c client.Client, namespace, backendRef string) (llm.Provider, error) {
+	backend, err := lookupAIBackend(ctx, c, namespace, backendRef)
+	if err != nil {
+		if provider, ok := legacyProviderFromEnv(); ok {
+			return provider, nil
+		}
+		return nil, err
 	}
 
-	reqBody := AIRequest{
-		Model:       modelName,
-		Messages:    buildPrompt(podName, triggerReason, logs),
-		Temperature: 0.2,
-		ResponseFormat: &ResponseFormat{
-			Type: "json_object",
-		},
+	cfg := llm.Config{
+		Backend:         backend.Spec.Backend,
+		BaseURL:         backend.Spec.BaseURL,
+		Model:           backend.Spec.Model,
+		AzureAPIVersion: backend.Spec.AzureAPIVersion,
 	}
 
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("序列化请求失败: %v", err)
+	if backend.Spec.SecretRef != nil {
+		var secret corev1.Secret
+		secretKey := client.ObjectKey{Namespace: backend.Namespace, Name: backend.Spec.SecretRef.Name}
+		if err := c.Get(ctx, secretKey, &secret); err != nil {
+			return nil, fmt.Errorf("读取 AIBackend %s 关联的 Secret %s 失败: %v", backend.Name, backend.Spec.SecretRef.Name, err)
+		}
+		cfg.APIKey = string(secret.Data["apiKey"])
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+apiKey)
+	return llm.NewProvider(cfg)
+}
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("调用 AI 接口失败: %v", err)
+// lookupAIBackend 按名称查找 AIBackend；backendRef 为空时回退到命名空间内 spec.default=true 的那一个
+func lookupAIBackend(ctx context.Context, c client.Client, namespace, backendRef string) (*diagnosticv1.AIBackend, error) {
+	if backendRef != "" {
+		var backend diagnosticv1.AIBackend
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: backendRef}, &backend); err != nil {
+			return nil, fmt.Errorf("获取 AIBackend %s/%s 失败: %v", namespace, backendRef, err)
+		}
+		return &backend, nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("AI 接口返回非 200 状态码: %d", resp.StatusCode)
+	var list diagnosticv1.AIBackendList
+	if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("列出命名空间 %s 下的 AIBackend 失败: %v", namespace, err)
 	}
-
-	var aiResp AIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&aiResp); err != nil {
-		return nil, fmt.Errorf("解析 AI 响应失败: %v", err)
+	for i := range list.Items {
+		if list.Items[i].Spec.Default {
+			return &list.Items[i], nil
+		}
 	}
+	return nil, fmt.Errorf("命名空间 %s 下未找到默认 AIBackend，且未指定 aiBackendRef", namespace)
+}
 
-	if len(aiResp.Choices) == 0 {
-		return nil, fmt.Errorf("AI 响应内容为空")
+// legacyProviderFromEnv 兼容引入 AIBackend CRD 之前依赖环境变量的部署方式
+func legacyProviderFromEnv() (llm.Provider, bool) {
+	apiKey := os.Getenv("AI_API_KEY")
+	apiURL := os.Getenv("AI_API_URL")
+	modelName := os.Getenv("AI_MODEL")
+	if apiKey == "" || apiURL == "" {
+		return nil, false
 	}
+	return llm.NewOpenAIProvider(llm.Config{Backend: "openai", BaseURL: apiURL, APIKey: apiKey, Model: modelName}), true
+}
 
-	content := aiResp.Choices[0].Message.Content
-
-	var result DiagnosisResult
-	if err := json.Unmarshal([]byte(content), &result); err != nil {
-		return nil, fmt.Errorf("反序列化 DiagnosisResult 失败, AI 返回内容为: %s, error: %v", content, err)
+// buildLogDigestOptions 把 spec.logDigest 转换为 logdigest.Options；EmbeddingModel 非空时
+// 尝试从 AI_EMBEDDING_API_URL/AI_EMBEDDING_API_KEY 环境变量构建 Embedding 服务客户端，
+// 未配置 AI_EMBEDDING_API_URL 时直接跳过基于 Embedding 的检索（AI_EMBEDDING_API_KEY 可留空），
+// 不影响切分与去重两步
+func (r *PodDiagnosisReconciler) buildLogDigestOptions(spec *diagnosticv1.LogDigestSpec, triggerReason string) logdigest.Options {
+	opts := logdigest.Options{TriggerReason: triggerReason}
+	if spec == nil {
+		return opts
 	}
+	opts.MaxTokens = int(spec.MaxTokens)
+	opts.KeepTailBytes = int(spec.KeepTailBytes)
+	opts.EmbeddingModel = spec.EmbeddingModel
 
-	return &result, nil
+	if spec.EmbeddingModel == "" {
+		return opts
+	}
+	embeddingURL := os.Getenv("AI_EMBEDDING_API_URL")
+	if embeddingURL == "" {
+		return opts
+	}
+	opts.EmbeddingProvider = logdigest.NewHTTPEmbeddingProvider(embeddingURL, os.Getenv("AI_EMBEDDING_API_KEY"), spec.EmbeddingModel)
+	return opts
 }
 func (r *PodDiagnosisReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := logf.FromContext(ctx)
@@ -170,8 +207,21 @@ func (r *PodDiagnosisReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		tailLines = 100
 	}
 
-	logger.Info("开始获取异常 Pod 日志", "Pod", targetPodName, "Namespace", namespace)
-	logs, err := r.getPodLogs(ctx, namespace, targetPodName, tailLines)
+	targetClient, targetClientset, err := r.resolveTargetClients(ctx, &diagnosis)
+	if err != nil {
+		logger.Error(err, "解析目标集群客户端失败")
+		base := diagnosis.DeepCopy()
+		diagnosis.Status.Phase = "Failed"
+		diagnosis.Status.RootCause = fmt.Sprintf("解析目标集群失败: %v", err)
+		diagnosis.Status.DiagnosisTime = &metav1.Time{Time: time.Now()}
+		if patchErr := r.Status().Patch(ctx, &diagnosis, client.MergeFrom(base)); patchErr != nil {
+			logger.Error(patchErr, "更新 Failed 状态失败")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("开始获取异常 Pod 日志", "Pod", targetPodName, "Namespace", namespace, "cluster", diagnosis.Spec.ClusterRef)
+	logs, err := r.getPodLogs(ctx, targetClientset, namespace, targetPodName, tailLines)
 	if err != nil {
 		logger.Error(err, "获取 Pod 日志失败")
 		base := diagnosis.DeepCopy()
@@ -185,11 +235,46 @@ func (r *PodDiagnosisReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	logger.Info("成功抓取日志，准备进行 AI 诊断", "LogLength", len(logs))
+
+	digestResult, err := logdigest.Digest(ctx, logs, r.buildLogDigestOptions(diagnosis.Spec.LogDigest, diagnosis.Spec.TriggerReason))
+	if err != nil {
+		logger.Error(err, "日志预处理失败，回退为使用原始日志")
+		digestResult = &logdigest.Result{Text: logs, Stats: logdigest.Stats{RawBytes: int32(len(logs)), DigestedBytes: int32(len(logs))}}
+	}
+	logs = digestResult.Text
+	logger.Info("日志预处理完成", "RawBytes", digestResult.Stats.RawBytes, "DigestedBytes", digestResult.Stats.DigestedBytes, "UniqueRecords", digestResult.Stats.UniqueRecords)
+
+	var targetPod corev1.Pod
+	var renderedContext string
+	podKey := client.ObjectKey{Namespace: namespace, Name: targetPodName}
+	if err := targetClient.Get(ctx, podKey, &targetPod); err != nil {
+		logger.Info("获取目标 Pod 失败，补充上下文将为空", "Pod", targetPodName, "error", err)
+	} else {
+		collector := &diagctx.Collector{Client: targetClient, Clientset: targetClientset}
+		diagContext := collector.Collect(ctx, &targetPod, diagEventLimit)
+		// 在拼入 Prompt 之前脱敏：renderedContext 会随 buildPrompt 发往第三方 AI 接口，
+		// 不能只在落盘 Status.Context 时才脱敏，否则密钥会明文离开集群
+		renderedContext = diagctx.Redact(diagctx.Render(diagContext))
+	}
+
+	provider, err := resolveProvider(ctx, r.Client, diagnosis.Namespace, diagnosis.Spec.AIBackendRef)
+	if err != nil {
+		logger.Error(err, "解析 AI 后端失败")
+		base := diagnosis.DeepCopy()
+		diagnosis.Status.Phase = "Failed"
+		diagnosis.Status.RootCause = fmt.Sprintf("解析 AI 后端失败: %v", err)
+		diagnosis.Status.DiagnosisTime = &metav1.Time{Time: time.Now()}
+		if patchErr := r.Status().Patch(ctx, &diagnosis, client.MergeFrom(base)); patchErr != nil {
+			logger.Error(patchErr, "更新 Failed 状态失败")
+		}
+		return ctrl.Result{}, nil
+	}
+
 	logger.Info("开始调用 AI 进行故障诊断。。。")
 
 	aiCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
 	defer cancel()
-	diagnosisResult, err := callAIEndpoint(aiCtx, targetPodName, diagnosis.Spec.TriggerReason, logs)
+	diagnosisResult, err := provider.Complete(aiCtx, buildPrompt(targetPodName, diagnosis.Spec.TriggerReason, logs, renderedContext))
 
 	if err != nil {
 		logger.Error(err, "AI 诊断过程发生错误")
@@ -205,15 +290,14 @@ func (r *PodDiagnosisReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	logger.Info("AI 诊断完成,准备回写结果", "RootCause", diagnosisResult.RootCause)
 
-	// 尝试绑定 Event 到目标 Pod
-	var targetPod corev1.Pod
-	podKey := client.ObjectKey{Namespace: diagnosis.Spec.Namespace, Name: diagnosis.Spec.PodName}
-	if err := r.Get(ctx, podKey, &targetPod); err != nil {
-		logger.Info("目标 Pod 已不存在，跳过 Event 绑定", "Pod", diagnosis.Spec.PodName)
-	} else {
+	// 尝试绑定 Event 到目标 Pod；Recorder 固定上报给本地集群的 API Server，
+	// 跨集群诊断（ClusterRef 非空）时目标 Pod 不在本地，跳过绑定
+	if diagnosis.Spec.ClusterRef == "" && targetPod.Name != "" {
 		r.Recorder.Eventf(&targetPod, corev1.EventTypeWarning, "AIDiagnosisResult",
 			"【AI 根因分析】: %s \n【修复建议】: %s", diagnosisResult.RootCause, diagnosisResult.Suggestion)
 		logger.Info("成功将诊断结果作为 Event 绑定至目标 Pod")
+	} else if diagnosis.Spec.ClusterRef == "" {
+		logger.Info("目标 Pod 已不存在，跳过 Event 绑定", "Pod", diagnosis.Spec.PodName)
 	}
 
 	// 更新诊断状态为 Completed
@@ -222,6 +306,28 @@ func (r *PodDiagnosisReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	diagnosis.Status.RootCause = diagnosisResult.RootCause
 	diagnosis.Status.Suggestion = diagnosisResult.Suggestion
 	diagnosis.Status.DiagnosisTime = &metav1.Time{Time: time.Now()}
+	diagnosis.Status.TokenUsage = &diagnosticv1.TokenUsage{
+		PromptTokens:     diagnosisResult.Usage.PromptTokens,
+		CompletionTokens: diagnosisResult.Usage.CompletionTokens,
+		TotalTokens:      diagnosisResult.Usage.TotalTokens,
+	}
+	if renderedContext != "" {
+		// renderedContext 在构建 Prompt 前已经脱敏过，这里直接落盘即可
+		diagnosis.Status.Context = renderedContext
+	}
+	diagnosis.Status.LogStats = &diagnosticv1.LogStats{
+		RawBytes:      digestResult.Stats.RawBytes,
+		DigestedBytes: digestResult.Stats.DigestedBytes,
+		UniqueRecords: digestResult.Stats.UniqueRecords,
+	}
+	for _, action := range diagnosisResult.Actions {
+		diagnosis.Status.Actions = append(diagnosis.Status.Actions, diagnosticv1.RemediationAction{
+			Kind:   action.Kind,
+			Params: action.Params,
+			Reason: action.Reason,
+			Phase:  "Pending",
+		})
+	}
 
 	if err := r.Status().Patch(ctx, &diagnosis, client.MergeFrom(base)); err != nil {
 		logger.Error(err, "更新 PodDiagnosis 状态为 Completed 时发生错误")
@@ -231,11 +337,13 @@ func (r *PodDiagnosisReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	return ctrl.Result{}, nil
 }
 
-func (r *PodDiagnosisReconciler) getPodLogs(ctx context.Context, namespace, podName string, tailLines int64) (string, error) {
+// getPodLogs 从 clientset 指向的集群拉取 Pod 日志；clientset 由调用方通过
+// resolveTargetClients 解析得到，可能是本地集群也可能是 ClusterRegistry 构建出的远程集群
+func (r *PodDiagnosisReconciler) getPodLogs(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName string, tailLines int64) (string, error) {
 	podLogOpts := corev1.PodLogOptions{
 		TailLines: &tailLines,
 	}
-	req := r.Clientset.CoreV1().Pods(namespace).GetLogs(podName, &podLogOpts)
+	req := clientset.CoreV1().Pods(namespace).GetLogs(podName, &podLogOpts)
 	podLogsStream, err := req.Stream(ctx)
 	if err != nil {
 		return "", fmt.Errorf("打开日志流失败: %v", err)
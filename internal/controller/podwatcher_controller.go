@@ -15,9 +15,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	diagnosticv1 "github.com/sxslzs/ai-diagnostic-operator/api/v1"
+	"github.com/sxslzs/ai-diagnostic-operator/pkg/analyzer"
 )
 
-// PodWatcherReconciler 监听 Pod 失败事件，自动创建 PodDiagnosis
+// PodWatcherReconciler 监听 Pod 失败事件，自动创建 PodDiagnosis。
+// 故障判定本身委托给 analyzer.Get("Pod")（与 ResourceWatcherReconciler 共用同一套
+// podAnalyzer 判定逻辑），避免在这里重复硬编码一份 isPodFailed；PodDiagnosis 仍由本
+// reconciler 创建而非 ResourceDiagnosis，因为只有前者挂了抓日志/AI 调用/自动修复的完整流水线。
 type PodWatcherReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
@@ -37,8 +41,16 @@ func (r *PodWatcherReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	// 判断 Pod 是否处于失败状态
-	if !isPodFailed(&pod) {
+	a, ok := analyzer.Get("Pod")
+	if !ok {
+		return ctrl.Result{}, fmt.Errorf("未找到 Pod 类型对应的 Analyzer")
+	}
+	findings, err := a.Analyze(ctx, r.Client, &pod)
+	if err != nil {
+		logger.Error(err, "分析器执行失败", "pod", pod.Name)
+		return ctrl.Result{}, err
+	}
+	if len(findings) == 0 {
 		return ctrl.Result{}, nil
 	}
 
@@ -73,7 +85,7 @@ func (r *PodWatcherReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		Spec: diagnosticv1.PodDiagnosisSpec{
 			PodName:       pod.Name,
 			Namespace:     pod.Namespace,
-			TriggerReason: fmt.Sprintf("Pod entered failed state: %s", getFailureReason(&pod)),
+			TriggerReason: summarizeFindings(findings),
 			TailLines:     100, // 默认行数，可配置
 		},
 	}
@@ -100,7 +112,7 @@ func (r *PodWatcherReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		WithEventFilter(predicate.Funcs{
 			CreateFunc: func(e event.CreateEvent) bool {
 				pod, ok := e.Object.(*corev1.Pod)
-				return ok && isPodFailed(pod)
+				return ok && podHasFindings(pod)
 			},
 			UpdateFunc: func(e event.UpdateEvent) bool {
 				oldPod, okOld := e.ObjectOld.(*corev1.Pod)
@@ -108,8 +120,8 @@ func (r *PodWatcherReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				if !okOld || !okNew {
 					return false
 				}
-				// 仅当从非失败状态变为失败状态时触发
-				return !isPodFailed(oldPod) && isPodFailed(newPod)
+				// 仅当从无 Finding 变为有 Finding 时触发，避免对同一故障反复入队
+				return !podHasFindings(oldPod) && podHasFindings(newPod)
 			},
 			DeleteFunc:  func(e event.DeleteEvent) bool { return false },
 			GenericFunc: func(e event.GenericEvent) bool { return false },
@@ -117,63 +129,13 @@ func (r *PodWatcherReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func isPodFailed(pod *corev1.Pod) bool {
-    // 1. Phase 为 Failed
-    if pod.Status.Phase == corev1.PodFailed {
-        return true
-    }
-    // 2. Phase 为 Pending 且存在调度失败或镜像拉取失败
-    if pod.Status.Phase == corev1.PodPending {
-        // 检查调度失败
-        for _, cond := range pod.Status.Conditions {
-            if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Reason == corev1.PodReasonUnschedulable {
-                return true
-            }
-        }
-        // 检查容器等待状态中的错误
-        for _, status := range pod.Status.ContainerStatuses {
-            if status.State.Waiting != nil {
-                reason := status.State.Waiting.Reason
-                switch reason {
-                case "ImagePullBackOff", "ErrImagePull", "CrashLoopBackOff", "CreateContainerError":
-                    return true
-                }
-            }
-        }
-    }
-    // 3. 所有容器已终止且至少一个退出码非零（CrashLoopBackOff 时可能所有容器已终止）
-    allTerminated := true
-    hasNonZero := false
-    for _, status := range pod.Status.ContainerStatuses {
-        if status.State.Terminated == nil {
-            allTerminated = false
-            // 如果容器在等待状态且是错误原因，也视为失败
-            if status.State.Waiting != nil {
-                reason := status.State.Waiting.Reason
-                if reason == "CrashLoopBackOff" || reason == "CreateContainerError" {
-                    return true
-                }
-            }
-        } else if status.State.Terminated.ExitCode != 0 {
-            hasNonZero = true
-        }
-    }
-    return allTerminated && hasNonZero
-}
-// getFailureReason 提取简要失败原因
-func getFailureReason(pod *corev1.Pod) string {
-	if pod.Status.Phase == corev1.PodFailed {
-		for _, cond := range pod.Status.Conditions {
-			if cond.Type == corev1.PodReasonUnschedulable && cond.Status == corev1.ConditionTrue {
-				return "Unschedulable: " + cond.Message
-			}
-		}
-	}
-	for _, status := range pod.Status.ContainerStatuses {
-		if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
-			return fmt.Sprintf("Container %s exited with code %d: %s",
-				status.Name, status.State.Terminated.ExitCode, status.State.Terminated.Reason)
-		}
+// podHasFindings 是事件过滤阶段使用的轻量判定：podAnalyzer 不依赖 reader 查询其他资源，
+// 这里传 nil 即可；一旦 podAnalyzer 将来需要读取集群状态，这个快捷方式需要同步更新
+func podHasFindings(pod *corev1.Pod) bool {
+	a, ok := analyzer.Get("Pod")
+	if !ok {
+		return false
 	}
-	return "unknown failure"
+	findings, err := a.Analyze(context.Background(), nil, pod)
+	return err == nil && len(findings) > 0
 }
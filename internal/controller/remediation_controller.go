@@ -0,0 +1,352 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	diagnosticv1 "github.com/sxslzs/ai-diagnostic-operator/api/v1"
+	"github.com/sxslzs/ai-diagnostic-operator/pkg/clusters"
+)
+
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=remediationpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=remediationpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;delete;patch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;delete
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;patch
+// +kubebuilder:rbac:groups=apps,resources=replicasets;statefulsets,verbs=get
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=patch
+
+// RemediationReconciler 在 PodDiagnosis 给出 Actions 建议后，依据对应命名空间的
+// RemediationPolicy 决定这些动作是直接执行、等待审批还是仅作 DryRun 记录，
+// 并将执行结果回写到 PodDiagnosis.Status.Actions[i]
+type RemediationReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Clientset *kubernetes.Clientset
+	Recorder  record.EventRecorder
+	// ClusterRegistry 按 spec.clusterRef 解析出目标集群的客户端；未设置时所有诊断都视为本地集群
+	ClusterRegistry *clusters.Registry
+}
+
+func (r *RemediationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var diagnosis diagnosticv1.PodDiagnosis
+	if err := r.Get(ctx, req.NamespacedName, &diagnosis); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if diagnosis.Status.Phase != "Completed" {
+		return ctrl.Result{}, nil
+	}
+
+	pending := false
+	for _, action := range diagnosis.Status.Actions {
+		if action.Phase == "Pending" {
+			pending = true
+			break
+		}
+	}
+	if !pending {
+		return ctrl.Result{}, nil
+	}
+
+	policy, err := r.lookupPolicy(ctx, diagnosis.Namespace)
+	if err != nil {
+		logger.Error(err, "查询 RemediationPolicy 失败")
+		return ctrl.Result{}, err
+	}
+
+	targetClient, targetClientset, err := r.resolveTargetClients(ctx, &diagnosis)
+	if err != nil {
+		logger.Error(err, "解析目标集群客户端失败")
+		return ctrl.Result{}, err
+	}
+
+	base := diagnosis.DeepCopy()
+	for i := range diagnosis.Status.Actions {
+		action := &diagnosis.Status.Actions[i]
+		if action.Phase != "Pending" {
+			continue
+		}
+		r.processAction(ctx, targetClient, targetClientset, &diagnosis, policy, action)
+	}
+
+	if err := r.Status().Patch(ctx, &diagnosis, client.MergeFrom(base)); err != nil {
+		logger.Error(err, "回写 Actions 执行结果失败")
+		return ctrl.Result{}, err
+	}
+
+	if policy != nil {
+		if err := r.markPolicyApplied(ctx, policy); err != nil {
+			logger.Error(err, "回写 RemediationPolicy.Status.LastAppliedTime 失败")
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// markPolicyApplied 记录本次有动作依据该策略被处理的时间，便于操作者通过
+// kubectl get remediationpolicy 判断某条策略是否仍在被实际使用
+func (r *RemediationReconciler) markPolicyApplied(ctx context.Context, policy *diagnosticv1.RemediationPolicy) error {
+	base := policy.DeepCopy()
+	now := metav1.Time{Time: time.Now()}
+	policy.Status.LastAppliedTime = &now
+	return r.Status().Patch(ctx, policy, client.MergeFrom(base))
+}
+
+// lookupPolicy 在诊断所在命名空间内查找适用的 RemediationPolicy；找不到时视为未配置，
+// 一律按最保守的 DryRun 处理
+func (r *RemediationReconciler) lookupPolicy(ctx context.Context, namespace string) (*diagnosticv1.RemediationPolicy, error) {
+	var list diagnosticv1.RemediationPolicyList
+	if err := r.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		p := &list.Items[i]
+		if p.Spec.Namespace == "" || p.Spec.Namespace == namespace {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveTargetClients 复用 PodDiagnosis 的 clusterRef 解析逻辑：留空时使用本地集群
+func (r *RemediationReconciler) resolveTargetClients(ctx context.Context, diagnosis *diagnosticv1.PodDiagnosis) (client.Client, *kubernetes.Clientset, error) {
+	if diagnosis.Spec.ClusterRef == "" {
+		return r.Client, r.Clientset, nil
+	}
+	if r.ClusterRegistry == nil {
+		return nil, nil, fmt.Errorf("未配置 ClusterRegistry，无法解析 clusterRef %q", diagnosis.Spec.ClusterRef)
+	}
+	cluster, err := r.ClusterRegistry.Get(ctx, diagnosis.Namespace, diagnosis.Spec.ClusterRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cluster.Client, cluster.Clientset, nil
+}
+
+// processAction 依据策略决定某个动作的放行情况，并在允许执行时落地变更，
+// 最终把结果写回 action.Phase/action.Result
+func (r *RemediationReconciler) processAction(ctx context.Context, targetClient client.Client, targetClientset *kubernetes.Clientset, diagnosis *diagnosticv1.PodDiagnosis, policy *diagnosticv1.RemediationPolicy, action *diagnosticv1.RemediationAction) {
+	logger := logf.FromContext(ctx)
+	now := metav1.Time{Time: time.Now()}
+	action.ExecutedAt = &now
+
+	if policy == nil {
+		action.Phase = "Skipped"
+		action.Result = "未找到适用的 RemediationPolicy，默认按 DryRun 处理，不执行任何变更"
+		return
+	}
+	if !actionAllowed(policy, action.Kind) {
+		action.Phase = "Skipped"
+		action.Result = fmt.Sprintf("动作类型 %s 未在命名空间 %s 的 RemediationPolicy 中被允许", action.Kind, diagnosis.Namespace)
+		return
+	}
+
+	switch policy.Spec.Mode {
+	case diagnosticv1.RemediationModeAuto:
+		// 直接放行
+	case diagnosticv1.RemediationModeRequireApproval:
+		if !diagnosis.Spec.Approved {
+			action.Phase = "Pending"
+			action.Result = "等待人工审批：请在确认无误后将 PodDiagnosis.spec.approved 置为 true"
+			return
+		}
+	default:
+		action.Phase = "Skipped"
+		action.Result = "RemediationPolicy 处于 DryRun 模式，仅记录建议动作，不执行实际变更"
+		return
+	}
+
+	result, err := r.executeAction(ctx, targetClient, targetClientset, diagnosis, action)
+	if err != nil {
+		action.Phase = "Failed"
+		action.Result = err.Error()
+		logger.Error(err, "执行修复动作失败", "kind", action.Kind, "diagnosis", diagnosis.Name)
+		r.recordEvent(ctx, targetClient, diagnosis, corev1.EventTypeWarning, "RemediationFailed", fmt.Sprintf("动作 %s 执行失败: %v", action.Kind, err))
+		return
+	}
+	action.Phase = "Succeeded"
+	action.Result = result
+	logger.Info("修复动作执行成功", "kind", action.Kind, "diagnosis", diagnosis.Name, "result", result)
+	r.recordEvent(ctx, targetClient, diagnosis, corev1.EventTypeNormal, "RemediationSucceeded", fmt.Sprintf("动作 %s 执行成功: %s", action.Kind, result))
+}
+
+func actionAllowed(policy *diagnosticv1.RemediationPolicy, kind string) bool {
+	for _, allowed := range policy.Spec.AllowedActions {
+		if allowed == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// recordEvent 尝试把执行结果作为 Event 绑定到目标 Pod；拿不到 Pod 时退化为不记录，不影响主流程
+func (r *RemediationReconciler) recordEvent(ctx context.Context, targetClient client.Client, diagnosis *diagnosticv1.PodDiagnosis, eventType, reason, message string) {
+	if r.Recorder == nil || diagnosis.Spec.ClusterRef != "" {
+		return
+	}
+	var pod corev1.Pod
+	podKey := client.ObjectKey{Namespace: diagnosis.Spec.Namespace, Name: diagnosis.Spec.PodName}
+	if err := targetClient.Get(ctx, podKey, &pod); err != nil {
+		return
+	}
+	r.Recorder.Event(&pod, eventType, reason, message)
+}
+
+// executeAction 按动作类型对目标集群做出实际变更
+func (r *RemediationReconciler) executeAction(ctx context.Context, targetClient client.Client, targetClientset *kubernetes.Clientset, diagnosis *diagnosticv1.PodDiagnosis, action *diagnosticv1.RemediationAction) (string, error) {
+	namespace := diagnosis.Spec.Namespace
+	podName := diagnosis.Spec.PodName
+
+	switch action.Kind {
+	case "RestartPod":
+		if err := targetClientset.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return "", fmt.Errorf("删除 Pod 以触发重建失败: %v", err)
+		}
+		return fmt.Sprintf("已删除 Pod %s/%s，等待控制器重建", namespace, podName), nil
+
+	case "IncreaseMemoryLimit":
+		memory := action.Params["memory"]
+		if memory == "" {
+			return "", fmt.Errorf("缺少 params.memory 参数")
+		}
+		if _, err := resource.ParseQuantity(memory); err != nil {
+			return "", fmt.Errorf("params.memory 不是合法的资源值 %q: %v", memory, err)
+		}
+		container := action.Params["container"]
+		if container == "" {
+			return "", fmt.Errorf("缺少 params.container 参数，无法定位要调整的容器")
+		}
+
+		// 存量 Pod 的 spec.containers[].resources 在标准 API Server（未开启 in-place pod
+		// vertical scaling alpha 特性门）上是不可变字段，直接 Patch live Pod 必定失败；
+		// 真正生效的做法是调整所属 Deployment/StatefulSet 的 Pod 模板，交由控制器滚动发布
+		var pod corev1.Pod
+		if err := targetClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: podName}, &pod); err != nil {
+			return "", fmt.Errorf("获取 Pod 以定位所属工作负载失败: %v", err)
+		}
+		ownerKind, ownerName, err := findOwningWorkload(ctx, targetClient, &pod)
+		if err != nil {
+			return "", err
+		}
+		patch := buildWorkloadResourcePatch(container, "memory", memory)
+		if err := patchWorkloadTemplate(ctx, targetClient, namespace, ownerKind, ownerName, patch); err != nil {
+			return "", fmt.Errorf("提交内存限额补丁失败: %v", err)
+		}
+		return fmt.Sprintf("已将 %s %s/%s 的容器 %s 内存限额调整为 %s（下一轮滚动发布生效）", ownerKind, namespace, ownerName, container, memory), nil
+
+	case "PatchImage":
+		image := action.Params["image"]
+		if image == "" {
+			return "", fmt.Errorf("缺少 params.image 参数")
+		}
+		container := action.Params["container"]
+		if container == "" {
+			return "", fmt.Errorf("缺少 params.container 参数，无法定位要调整的容器")
+		}
+		patch := buildImagePatch(container, image)
+		if err := targetClient.Patch(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: podName}}, client.RawPatch(types.StrategicMergePatchType, patch)); err != nil {
+			return "", fmt.Errorf("提交镜像补丁失败: %v", err)
+		}
+		return fmt.Sprintf("已将 Pod %s/%s 镜像调整为 %s", namespace, podName, image), nil
+
+	case "DeletePVC":
+		pvcName := action.Params["pvc"]
+		if pvcName == "" {
+			return "", fmt.Errorf("缺少 params.pvc 参数")
+		}
+		if err := targetClientset.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, pvcName, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return "", fmt.Errorf("删除 PVC %s 失败: %v", pvcName, err)
+		}
+		return fmt.Sprintf("已删除 PVC %s/%s", namespace, pvcName), nil
+
+	case "CordonNode":
+		var pod corev1.Pod
+		if err := targetClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: podName}, &pod); err != nil {
+			return "", fmt.Errorf("获取 Pod 以确定所在节点失败: %v", err)
+		}
+		if pod.Spec.NodeName == "" {
+			return "", fmt.Errorf("Pod 尚未被调度到任何节点，无法 Cordon")
+		}
+		patch := []byte(`{"spec":{"unschedulable":true}}`)
+		if _, err := targetClientset.CoreV1().Nodes().Patch(ctx, pod.Spec.NodeName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			return "", fmt.Errorf("Cordon 节点 %s 失败: %v", pod.Spec.NodeName, err)
+		}
+		return fmt.Sprintf("已 Cordon 节点 %s", pod.Spec.NodeName), nil
+
+	default:
+		return "", fmt.Errorf("不支持的动作类型: %s", action.Kind)
+	}
+}
+
+// findOwningWorkload 沿 Pod -> ReplicaSet -> Deployment，或 Pod -> StatefulSet 向上查找
+// 所属工作负载，与 pkg/diagctx.Collector 收集发布历史时使用的是同一条 OwnerReference 链路
+func findOwningWorkload(ctx context.Context, c client.Client, pod *corev1.Pod) (kind, name string, err error) {
+	for _, ref := range pod.OwnerReferences {
+		switch ref.Kind {
+		case "ReplicaSet":
+			var rs appsv1.ReplicaSet
+			if err := c.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: ref.Name}, &rs); err != nil {
+				continue
+			}
+			for _, rsRef := range rs.OwnerReferences {
+				if rsRef.Kind == "Deployment" {
+					return "Deployment", rsRef.Name, nil
+				}
+			}
+		case "StatefulSet":
+			return "StatefulSet", ref.Name, nil
+		}
+	}
+	return "", "", fmt.Errorf("Pod %s/%s 不属于任何 Deployment/StatefulSet，无法调整其容器资源", pod.Namespace, pod.Name)
+}
+
+// patchWorkloadTemplate 对 Deployment/StatefulSet 的 spec.template 应用 templatePatch（PodSpec 形状的补丁片段）
+func patchWorkloadTemplate(ctx context.Context, c client.Client, namespace, kind, name string, templatePatch []byte) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"spec":%s}}}`, templatePatch))
+	var obj client.Object
+	switch kind {
+	case "Deployment":
+		obj = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	case "StatefulSet":
+		obj = &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	default:
+		return fmt.Errorf("不支持的工作负载类型 %s", kind)
+	}
+	return c.Patch(ctx, obj, client.RawPatch(types.StrategicMergePatchType, patch))
+}
+
+// buildWorkloadResourcePatch 生成一个按 container 字段定位目标容器的 limits 补丁（PodSpec 形状）；
+// strategic merge patch 依赖 containers[].name 作为主键合并，因此必须显式指定容器名
+func buildWorkloadResourcePatch(container, resourceName, value string) []byte {
+	return []byte(fmt.Sprintf(`{"containers":[{"name":%q,"resources":{"limits":{%q:%q}}}]}`, container, resourceName, value))
+}
+
+// buildImagePatch 生成一个按 container 字段定位目标容器的镜像补丁
+func buildImagePatch(container, image string) []byte {
+	return []byte(fmt.Sprintf(`{"spec":{"containers":[{"name":%q,"image":%q}]}}`, container, image))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RemediationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&diagnosticv1.PodDiagnosis{}).
+		Named("remediation").
+		Complete(r)
+}
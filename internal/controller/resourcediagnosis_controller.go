@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	diagnosticv1 "github.com/sxslzs/ai-diagnostic-operator/api/v1"
+	"github.com/sxslzs/ai-diagnostic-operator/pkg/llm"
+)
+
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=resourcediagnoses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=resourcediagnoses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=aibackends,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// ResourceDiagnosisReconciler 是 PodDiagnosisReconciler 泛化到任意资源类型的版本：
+// ResourceWatcherReconciler 为 PVC/Ingress/HPA/PDB 等 11 种已注册的 analyzer.Analyzer 创建
+// ResourceDiagnosis 后，由本 reconciler 驱动实际的 AI 调用，否则这些 CR 会永远停留在空状态。
+// 与 PodDiagnosis 不同，ResourceDiagnosis 没有 stdout/stderr 可抓取（并非所有资源类型都有
+// Pod 意义上的日志），Prompt 仅基于 Spec.TargetRef 与 Spec.TriggerReason（分析器产出的
+// Finding 摘要）构建；AI 后端解析复用与 PodDiagnosisReconciler 相同的 resolveProvider。
+type ResourceDiagnosisReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// buildResourcePrompt 构建针对泛化资源诊断的 Prompt；不含日志，只有 Finding 摘要与资源标识
+func buildResourcePrompt(ref diagnosticv1.TargetRef, triggerReason string) []llm.Message {
+	systemPrompt := `你是一个资深的云原生架构师与 Kubernetes SRE 专家。
+你的任务是根据提供的 Kubernetes 资源异常信息，分析根本原因，并给出可执行的修复建议。
+请严格按照 JSON 格式输出，务必包含以下字段：
+1. "rootCause": 用一两句话简明扼要地概括根本原因。
+2. "suggestion": 给出具体的排查或修复指令。
+信息不足以下结论时，如实在 rootCause 中说明，不要臆造。
+不要输出任何 Markdown 标记符或其他多余的解释性文字。`
+
+	userPrompt := fmt.Sprintf(`
+【异常资源】: %s/%s (namespace=%s, apiVersion=%s)
+【触发诊断原因】: %s`, ref.Kind, ref.Name, ref.Namespace, ref.APIVersion, triggerReason)
+
+	return []llm.Message{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: userPrompt},
+	}
+}
+
+func (r *ResourceDiagnosisReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var diagnosis diagnosticv1.ResourceDiagnosis
+	if err := r.Get(ctx, req.NamespacedName, &diagnosis); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// 如果已经是终态，跳过处理
+	if diagnosis.Status.Phase == "Completed" || diagnosis.Status.Phase == "Failed" {
+		return ctrl.Result{}, nil
+	}
+
+	provider, err := resolveProvider(ctx, r.Client, diagnosis.Namespace, "")
+	if err != nil {
+		logger.Error(err, "解析 AI 后端失败")
+		r.markFailed(ctx, &diagnosis, fmt.Sprintf("解析 AI 后端失败: %v", err))
+		return ctrl.Result{}, nil
+	}
+
+	ref := diagnosis.Spec.TargetRef
+	logger.Info("开始调用 AI 进行资源诊断", "kind", ref.Kind, "name", ref.Name, "namespace", ref.Namespace)
+
+	aiCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
+	defer cancel()
+	diagnosisResult, err := provider.Complete(aiCtx, buildResourcePrompt(ref, diagnosis.Spec.TriggerReason))
+	if err != nil {
+		logger.Error(err, "AI 诊断过程发生错误")
+		r.markFailed(ctx, &diagnosis, fmt.Sprintf("诊断失败: %v", err))
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("AI 诊断完成，准备回写结果", "RootCause", diagnosisResult.RootCause)
+
+	base := diagnosis.DeepCopy()
+	diagnosis.Status.Phase = "Completed"
+	diagnosis.Status.RootCause = diagnosisResult.RootCause
+	diagnosis.Status.Suggestion = diagnosisResult.Suggestion
+	diagnosis.Status.DiagnosisTime = &metav1.Time{Time: time.Now()}
+	if err := r.Status().Patch(ctx, &diagnosis, client.MergeFrom(base)); err != nil {
+		logger.Error(err, "更新 ResourceDiagnosis 状态为 Completed 时发生错误")
+		return ctrl.Result{}, err
+	}
+	logger.Info("ResourceDiagnosis 状态更新成功！流程结束。")
+	return ctrl.Result{}, nil
+}
+
+// markFailed 把诊断状态置为 Failed 并记录原因；回写失败时仅记录日志，不影响主流程返回值
+func (r *ResourceDiagnosisReconciler) markFailed(ctx context.Context, diagnosis *diagnosticv1.ResourceDiagnosis, reason string) {
+	base := diagnosis.DeepCopy()
+	diagnosis.Status.Phase = "Failed"
+	diagnosis.Status.RootCause = reason
+	diagnosis.Status.DiagnosisTime = &metav1.Time{Time: time.Now()}
+	if err := r.Status().Patch(ctx, diagnosis, client.MergeFrom(base)); err != nil {
+		logf.FromContext(ctx).Error(err, "更新 Failed 状态失败")
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ResourceDiagnosisReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&diagnosticv1.ResourceDiagnosis{}).
+		Named("resourcediagnosis").
+		Complete(r)
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	diagnosticv1 "github.com/sxslzs/ai-diagnostic-operator/api/v1"
+	"github.com/sxslzs/ai-diagnostic-operator/pkg/analyzer"
+)
+
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=resourcediagnoses,verbs=create;get;list;update;patch;delete
+// +kubebuilder:rbac:groups=diagnostic.sre.example.com,resources=resourcediagnoses/status,verbs=get
+
+// ResourceWatcherReconciler 是 PodWatcherReconciler 的泛化版本：不再硬编码某一种资源的
+// 失败判定逻辑，而是按 Kind 查找 analyzer.Analyzer，并只在产生 Finding 时才创建
+// ResourceDiagnosis（从而只在有问题时才触发 AI 调用）。
+// 每种受监控的资源类型对应一个 ResourceWatcherReconciler 实例，在 main.go 中通过
+// NewObject 绑定具体的 client.Object 构造函数后分别 SetupWithManager。
+type ResourceWatcherReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Kind 本实例负责的资源类型，必须与对应 analyzer.Analyzer.Kind() 一致
+	Kind string
+	// APIVersion 目标资源的 API 版本，写入生成的 ResourceDiagnosis.Spec.TargetRef
+	APIVersion string
+	// NewObject 构造一个空的目标资源对象，用于 r.Get
+	NewObject func() client.Object
+}
+
+// Reconcile 处理单个目标资源对象：查找对应 Analyzer、执行分析、按需创建 ResourceDiagnosis
+func (r *ResourceWatcherReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	a, ok := analyzer.Get(r.Kind)
+	if !ok {
+		return ctrl.Result{}, fmt.Errorf("未找到资源类型 %s 对应的 Analyzer", r.Kind)
+	}
+
+	obj := r.NewObject()
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	findings, err := a.Analyze(ctx, r.Client, obj)
+	if err != nil {
+		logger.Error(err, "分析器执行失败", "kind", r.Kind, "name", req.Name)
+		return ctrl.Result{}, err
+	}
+	if len(findings) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	diagnosisList := &diagnosticv1.ResourceDiagnosisList{}
+	if err := r.List(ctx, diagnosisList,
+		client.InNamespace(req.Namespace),
+		client.MatchingFields{"spec.targetRef.name": req.Name}); err != nil {
+		logger.Error(err, "无法查询现有的 ResourceDiagnosis")
+		return ctrl.Result{}, err
+	}
+	for _, d := range diagnosisList.Items {
+		if d.Spec.TargetRef.Kind == r.Kind && d.Status.Phase != "Completed" && d.Status.Phase != "Failed" {
+			logger.Info("该资源已有正在进行的诊断，跳过", "diagnosis", d.Name)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	diagnosis := &diagnosticv1.ResourceDiagnosis{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-diagnosis-", strings.ToLower(r.Kind), req.Name),
+			Namespace:    req.Namespace,
+			Labels: map[string]string{
+				"diagnosed-resource": req.Name,
+				"diagnosed-kind":     r.Kind,
+				"created-by":         "resource-watcher",
+			},
+		},
+		Spec: diagnosticv1.ResourceDiagnosisSpec{
+			TargetRef: diagnosticv1.TargetRef{
+				Kind:       r.Kind,
+				APIVersion: r.APIVersion,
+				Name:       req.Name,
+				Namespace:  req.Namespace,
+			},
+			TriggerReason: summarizeFindings(findings),
+			TailLines:     100,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(obj, diagnosis, r.Scheme); err != nil {
+		logger.Error(err, "无法设置 OwnerReference")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.Create(ctx, diagnosis); err != nil {
+		logger.Error(err, "无法创建 ResourceDiagnosis")
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("成功为异常资源创建诊断任务", "kind", r.Kind, "name", req.Name, "diagnosis", diagnosis.Name)
+	return ctrl.Result{}, nil
+}
+
+// summarizeFindings 将分析器产出的 Finding 列表拼接为人类可读的触发原因
+func summarizeFindings(findings []analyzer.Finding) string {
+	parts := make([]string, 0, len(findings))
+	for _, f := range findings {
+		parts = append(parts, fmt.Sprintf("[%s] %s: %s", f.Severity, f.Reason, f.Message))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// SetupWithManager 设置控制器；NewObject 必须在调用前被赋值
+func (r *ResourceWatcherReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.NewObject == nil {
+		return fmt.Errorf("ResourceWatcherReconciler(%s): NewObject 未设置", r.Kind)
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(r.NewObject()).
+		Named(fmt.Sprintf("resourcewatcher-%s", strings.ToLower(r.Kind))).
+		Complete(r)
+}
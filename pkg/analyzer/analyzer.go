@@ -0,0 +1,52 @@
+// Package analyzer 提供针对各类 Kubernetes 资源的可插拔故障检测能力。
+// 每种资源类型对应一个 Analyzer 实现，封装自己的失败判定逻辑，
+// ResourceWatcherReconciler 只负责按 Kind 分发，不再硬编码具体的判定条件。
+package analyzer
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Finding 描述在目标资源上发现的一项异常
+type Finding struct {
+	// Severity 严重程度，例如 Warning、Critical
+	Severity string
+	// Reason 简短原因标识，例如 "ScalingLimited"、"NoMatchingService"
+	Reason string
+	// Message 供人阅读的详细描述，会被拼接进 AI 诊断的触发原因
+	Message string
+}
+
+// Analyzer 定义了针对某一类资源的故障检测逻辑
+type Analyzer interface {
+	// Kind 返回该分析器负责处理的资源类型，与 TargetRef.Kind 对应
+	Kind() string
+	// Analyze 检查目标对象是否存在异常，返回发现的问题列表；无异常时返回空切片。
+	// reader 用于分析器在判定过程中查询关联资源（例如 Ingress 分析器查询其指向的 Service）。
+	Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error)
+}
+
+var registry = map[string]Analyzer{}
+
+// Register 将分析器注册到全局表中，供 ResourceWatcherReconciler 按 Kind 查找。
+// 约定在各分析器实现文件的 init() 中调用。
+func Register(a Analyzer) {
+	registry[a.Kind()] = a
+}
+
+// Get 按 Kind 查找已注册的分析器
+func Get(kind string) (Analyzer, bool) {
+	a, ok := registry[kind]
+	return a, ok
+}
+
+// Kinds 返回所有已注册的资源类型，供 manager 启动时构建对应的 Watch
+func Kinds() []string {
+	kinds := make([]string, 0, len(registry))
+	for k := range registry {
+		kinds = append(kinds, k)
+	}
+	return kinds
+}
@@ -0,0 +1,51 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type cronJobAnalyzer struct{}
+
+func init() {
+	Register(&cronJobAnalyzer{})
+}
+
+func (a *cronJobAnalyzer) Kind() string { return "CronJob" }
+
+func (a *cronJobAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	cj, ok := obj.(*batchv1.CronJob)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/cronjob: 期望 *batchv1.CronJob, 实际为 %T", obj)
+	}
+
+	if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+		return nil, nil
+	}
+
+	if cj.Status.LastScheduleTime == nil {
+		return nil, nil
+	}
+
+	if cj.Status.LastSuccessfulTime == nil {
+		return []Finding{{
+			Severity: "Warning",
+			Reason:   "NeverSucceeded",
+			Message:  fmt.Sprintf("CronJob %s/%s 自创建以来从未成功执行过一次", cj.Namespace, cj.Name),
+		}}, nil
+	}
+
+	if cj.Status.LastSuccessfulTime.Before(cj.Status.LastScheduleTime) {
+		return []Finding{{
+			Severity: "Warning",
+			Reason:   "LastRunFailed",
+			Message: fmt.Sprintf("CronJob %s/%s 最近一次调度 (%s) 晚于最近一次成功执行 (%s)",
+				cj.Namespace, cj.Name, cj.Status.LastScheduleTime, cj.Status.LastSuccessfulTime),
+		}}, nil
+	}
+
+	return nil, nil
+}
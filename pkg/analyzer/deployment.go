@@ -0,0 +1,52 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type deploymentAnalyzer struct{}
+
+func init() {
+	Register(&deploymentAnalyzer{})
+}
+
+func (a *deploymentAnalyzer) Kind() string { return "Deployment" }
+
+func (a *deploymentAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	dep, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/deployment: 期望 *appsv1.Deployment, 实际为 %T", obj)
+	}
+
+	var findings []Finding
+	for _, cond := range dep.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == "False" {
+			findings = append(findings, Finding{
+				Severity: "Critical",
+				Reason:   "ProgressDeadlineExceeded",
+				Message:  fmt.Sprintf("Deployment %s/%s 滚动更新超时未完成: %s", dep.Namespace, dep.Name, cond.Message),
+			})
+		}
+		if cond.Type == appsv1.DeploymentReplicaFailure && cond.Status == "True" {
+			findings = append(findings, Finding{
+				Severity: "Critical",
+				Reason:   "ReplicaFailure",
+				Message:  fmt.Sprintf("Deployment %s/%s 存在无法创建的副本: %s", dep.Namespace, dep.Name, cond.Message),
+			})
+		}
+	}
+
+	if dep.Spec.Replicas != nil && *dep.Spec.Replicas > 0 && dep.Status.AvailableReplicas == 0 && dep.Status.ObservedGeneration >= dep.Generation {
+		findings = append(findings, Finding{
+			Severity: "Warning",
+			Reason:   "NoAvailableReplicas",
+			Message:  fmt.Sprintf("Deployment %s/%s 期望副本数 %d，但可用副本数为 0", dep.Namespace, dep.Name, *dep.Spec.Replicas),
+		})
+	}
+
+	return findings, nil
+}
@@ -0,0 +1,51 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type hpaAnalyzer struct{}
+
+func init() {
+	Register(&hpaAnalyzer{})
+}
+
+func (a *hpaAnalyzer) Kind() string { return "HorizontalPodAutoscaler" }
+
+func (a *hpaAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/hpa: 期望 *autoscalingv2.HorizontalPodAutoscaler, 实际为 %T", obj)
+	}
+
+	var findings []Finding
+	for _, cond := range hpa.Status.Conditions {
+		if cond.Type == autoscalingv2.ScalingLimited && cond.Status == "True" {
+			findings = append(findings, Finding{
+				Severity: "Warning",
+				Reason:   "ScalingLimited",
+				Message:  fmt.Sprintf("HPA %s/%s 已达到 min/max 副本数限制: %s", hpa.Namespace, hpa.Name, cond.Message),
+			})
+		}
+		if cond.Type == autoscalingv2.AbleToScale && cond.Status == "False" {
+			findings = append(findings, Finding{
+				Severity: "Critical",
+				Reason:   "UnableToScale",
+				Message:  fmt.Sprintf("HPA %s/%s 无法执行伸缩: %s", hpa.Namespace, hpa.Name, cond.Message),
+			})
+		}
+		if cond.Type == autoscalingv2.ScalingActive && cond.Status == "False" {
+			findings = append(findings, Finding{
+				Severity: "Warning",
+				Reason:   "MetricsUnavailable",
+				Message:  fmt.Sprintf("HPA %s/%s 无法获取指标: %s", hpa.Namespace, hpa.Name, cond.Message),
+			})
+		}
+	}
+
+	return findings, nil
+}
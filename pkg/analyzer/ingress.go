@@ -0,0 +1,71 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type ingressAnalyzer struct{}
+
+func init() {
+	Register(&ingressAnalyzer{})
+}
+
+func (a *ingressAnalyzer) Kind() string { return "Ingress" }
+
+func (a *ingressAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	ing, ok := obj.(*networkingv1.Ingress)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/ingress: 期望 *networkingv1.Ingress, 实际为 %T", obj)
+	}
+
+	var findings []Finding
+	for _, svcName := range backendServiceNames(ing) {
+		var svc corev1.Service
+		err := reader.Get(ctx, client.ObjectKey{Namespace: ing.Namespace, Name: svcName}, &svc)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("analyzer/ingress: 查询 Service %s/%s 失败: %w", ing.Namespace, svcName, err)
+			}
+			findings = append(findings, Finding{
+				Severity: "Critical",
+				Reason:   "NoMatchingService",
+				Message:  fmt.Sprintf("Ingress %s/%s 引用的 Service %s 不存在", ing.Namespace, ing.Name, svcName),
+			})
+		}
+	}
+
+	if len(ing.Status.LoadBalancer.Ingress) == 0 {
+		findings = append(findings, Finding{
+			Severity: "Warning",
+			Reason:   "NoLoadBalancerAddress",
+			Message:  fmt.Sprintf("Ingress %s/%s 尚未分配到负载均衡地址", ing.Namespace, ing.Name),
+		})
+	}
+
+	return findings, nil
+}
+
+// backendServiceNames 汇总 Ingress 默认后端及各 rule 中引用的 Service 名称
+func backendServiceNames(ing *networkingv1.Ingress) []string {
+	var names []string
+	if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Service != nil {
+		names = append(names, ing.Spec.DefaultBackend.Service.Name)
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				names = append(names, path.Backend.Service.Name)
+			}
+		}
+	}
+	return names
+}
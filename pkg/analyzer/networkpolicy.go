@@ -0,0 +1,46 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type networkPolicyAnalyzer struct{}
+
+func init() {
+	Register(&networkPolicyAnalyzer{})
+}
+
+func (a *networkPolicyAnalyzer) Kind() string { return "NetworkPolicy" }
+
+func (a *networkPolicyAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	np, ok := obj.(*networkingv1.NetworkPolicy)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/networkpolicy: 期望 *networkingv1.NetworkPolicy, 实际为 %T", obj)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&np.Spec.PodSelector)
+	if err != nil {
+		return nil, fmt.Errorf("analyzer/networkpolicy: 解析 podSelector 失败: %v", err)
+	}
+
+	var pods corev1.PodList
+	if err := reader.List(ctx, &pods, client.InNamespace(np.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("analyzer/networkpolicy: 查询匹配的 Pod 失败: %v", err)
+	}
+
+	if len(pods.Items) == 0 {
+		return []Finding{{
+			Severity: "Warning",
+			Reason:   "NoMatchingPods",
+			Message:  fmt.Sprintf("NetworkPolicy %s/%s 的 podSelector 未匹配到任何 Pod，策略未生效", np.Namespace, np.Name),
+		}}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,48 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type nodeAnalyzer struct{}
+
+func init() {
+	Register(&nodeAnalyzer{})
+}
+
+func (a *nodeAnalyzer) Kind() string { return "Node" }
+
+func (a *nodeAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/node: 期望 *corev1.Node, 实际为 %T", obj)
+	}
+
+	var findings []Finding
+	for _, cond := range node.Status.Conditions {
+		switch cond.Type {
+		case corev1.NodeReady:
+			if cond.Status != corev1.ConditionTrue {
+				findings = append(findings, Finding{
+					Severity: "Critical",
+					Reason:   "NotReady",
+					Message:  fmt.Sprintf("节点 %s NotReady: %s", node.Name, cond.Message),
+				})
+			}
+		case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure:
+			if cond.Status == corev1.ConditionTrue {
+				findings = append(findings, Finding{
+					Severity: "Warning",
+					Reason:   string(cond.Type),
+					Message:  fmt.Sprintf("节点 %s 存在 %s: %s", node.Name, cond.Type, cond.Message),
+				})
+			}
+		}
+	}
+
+	return findings, nil
+}
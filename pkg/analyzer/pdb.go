@@ -0,0 +1,39 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type pdbAnalyzer struct{}
+
+func init() {
+	Register(&pdbAnalyzer{})
+}
+
+func (a *pdbAnalyzer) Kind() string { return "PodDisruptionBudget" }
+
+func (a *pdbAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	pdb, ok := obj.(*policyv1.PodDisruptionBudget)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/pdb: 期望 *policyv1.PodDisruptionBudget, 实际为 %T", obj)
+	}
+
+	if pdb.Status.ObservedGeneration < pdb.Generation {
+		return nil, nil
+	}
+
+	if pdb.Status.DisruptionsAllowed == 0 && pdb.Status.ExpectedPods > 0 {
+		return []Finding{{
+			Severity: "Warning",
+			Reason:   "NoDisruptionsAllowed",
+			Message: fmt.Sprintf("PDB %s/%s 当前不允许任何驱逐 (currentHealthy=%d, desiredHealthy=%d, expectedPods=%d)，可能阻塞节点维护",
+				pdb.Namespace, pdb.Name, pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy, pdb.Status.ExpectedPods),
+		}}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// podAnalyzer 承载原先散落在 PodWatcherReconciler 中的 isPodFailed 判定逻辑
+type podAnalyzer struct{}
+
+func init() {
+	Register(&podAnalyzer{})
+}
+
+func (a *podAnalyzer) Kind() string { return "Pod" }
+
+func (a *podAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/pod: 期望 *corev1.Pod, 实际为 %T", obj)
+	}
+
+	if pod.Status.Phase == corev1.PodFailed {
+		return []Finding{{Severity: "Critical", Reason: "PodFailed", Message: fmt.Sprintf("Pod %s/%s 处于 Failed 阶段", pod.Namespace, pod.Name)}}, nil
+	}
+
+	if pod.Status.Phase == corev1.PodPending {
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Reason == corev1.PodReasonUnschedulable {
+				return []Finding{{Severity: "Warning", Reason: "Unschedulable", Message: fmt.Sprintf("Pod %s/%s 无法调度: %s", pod.Namespace, pod.Name, cond.Message)}}, nil
+			}
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Waiting != nil {
+				switch status.State.Waiting.Reason {
+				case "ImagePullBackOff", "ErrImagePull", "CrashLoopBackOff", "CreateContainerError":
+					return []Finding{{Severity: "Warning", Reason: status.State.Waiting.Reason, Message: fmt.Sprintf("容器 %s 处于 %s: %s", status.Name, status.State.Waiting.Reason, status.State.Waiting.Message)}}, nil
+				}
+			}
+		}
+	}
+
+	allTerminated := true
+	hasNonZero := false
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated == nil {
+			allTerminated = false
+			if status.State.Waiting != nil {
+				reason := status.State.Waiting.Reason
+				if reason == "CrashLoopBackOff" || reason == "CreateContainerError" {
+					return []Finding{{Severity: "Warning", Reason: reason, Message: fmt.Sprintf("容器 %s 处于 %s", status.Name, reason)}}, nil
+				}
+			}
+		} else if status.State.Terminated.ExitCode != 0 {
+			hasNonZero = true
+		}
+	}
+	if allTerminated && hasNonZero {
+		return []Finding{{Severity: "Critical", Reason: "ContainersExited", Message: fmt.Sprintf("Pod %s/%s 所有容器已终止且存在非零退出码", pod.Namespace, pod.Name)}}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,41 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type pvcAnalyzer struct{}
+
+func init() {
+	Register(&pvcAnalyzer{})
+}
+
+func (a *pvcAnalyzer) Kind() string { return "PersistentVolumeClaim" }
+
+func (a *pvcAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	pvc, ok := obj.(*corev1.PersistentVolumeClaim)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/pvc: 期望 *corev1.PersistentVolumeClaim, 实际为 %T", obj)
+	}
+
+	switch pvc.Status.Phase {
+	case corev1.ClaimPending:
+		return []Finding{{
+			Severity: "Warning",
+			Reason:   "Pending",
+			Message:  fmt.Sprintf("PVC %s/%s 处于 Pending 状态，可能是 StorageClass 不存在或没有匹配的 PV", pvc.Namespace, pvc.Name),
+		}}, nil
+	case corev1.ClaimLost:
+		return []Finding{{
+			Severity: "Critical",
+			Reason:   "Lost",
+			Message:  fmt.Sprintf("PVC %s/%s 绑定的 PV 已丢失", pvc.Namespace, pvc.Name),
+		}}, nil
+	}
+
+	return nil, nil
+}
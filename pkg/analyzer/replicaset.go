@@ -0,0 +1,44 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type replicaSetAnalyzer struct{}
+
+func init() {
+	Register(&replicaSetAnalyzer{})
+}
+
+func (a *replicaSetAnalyzer) Kind() string { return "ReplicaSet" }
+
+func (a *replicaSetAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	rs, ok := obj.(*appsv1.ReplicaSet)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/replicaset: 期望 *appsv1.ReplicaSet, 实际为 %T", obj)
+	}
+
+	var findings []Finding
+	for _, cond := range rs.Status.Conditions {
+		if cond.Type == appsv1.ReplicaSetReplicaFailure && cond.Status == "True" {
+			findings = append(findings, Finding{
+				Severity: "Critical",
+				Reason:   "ReplicaFailure",
+				Message:  fmt.Sprintf("ReplicaSet %s/%s 无法创建副本: %s", rs.Namespace, rs.Name, cond.Message),
+			})
+		}
+	}
+	if rs.Spec.Replicas != nil && *rs.Spec.Replicas > 0 && rs.Status.AvailableReplicas == 0 && rs.Status.ObservedGeneration >= rs.Generation {
+		findings = append(findings, Finding{
+			Severity: "Warning",
+			Reason:   "NoAvailableReplicas",
+			Message:  fmt.Sprintf("ReplicaSet %s/%s 期望副本数 %d，但可用副本数为 0", rs.Namespace, rs.Name, *rs.Spec.Replicas),
+		})
+	}
+
+	return findings, nil
+}
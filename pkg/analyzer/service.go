@@ -0,0 +1,48 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type serviceAnalyzer struct{}
+
+func init() {
+	Register(&serviceAnalyzer{})
+}
+
+func (a *serviceAnalyzer) Kind() string { return "Service" }
+
+func (a *serviceAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	svc, ok := obj.(*corev1.Service)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/service: 期望 *corev1.Service, 实际为 %T", obj)
+	}
+
+	// ExternalName 类型或未设置 selector 的 Service 没有由 selector 维护的 endpoints，跳过
+	if svc.Spec.Type == corev1.ServiceTypeExternalName || len(svc.Spec.Selector) == 0 {
+		return nil, nil
+	}
+
+	var endpoints corev1.Endpoints
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: svc.Namespace, Name: svc.Name}, &endpoints); err != nil {
+		return nil, fmt.Errorf("analyzer/service: 查询 Endpoints 失败: %v", err)
+	}
+
+	ready := 0
+	for _, subset := range endpoints.Subsets {
+		ready += len(subset.Addresses)
+	}
+	if ready == 0 {
+		return []Finding{{
+			Severity: "Warning",
+			Reason:   "NoReadyEndpoints",
+			Message:  fmt.Sprintf("Service %s/%s 没有任何就绪的 Endpoint，selector 可能未匹配到任何 Pod", svc.Namespace, svc.Name),
+		}}, nil
+	}
+
+	return nil, nil
+}
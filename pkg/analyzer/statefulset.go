@@ -0,0 +1,42 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type statefulSetAnalyzer struct{}
+
+func init() {
+	Register(&statefulSetAnalyzer{})
+}
+
+func (a *statefulSetAnalyzer) Kind() string { return "StatefulSet" }
+
+func (a *statefulSetAnalyzer) Analyze(ctx context.Context, reader client.Reader, obj client.Object) ([]Finding, error) {
+	sts, ok := obj.(*appsv1.StatefulSet)
+	if !ok {
+		return nil, fmt.Errorf("analyzer/statefulset: 期望 *appsv1.StatefulSet, 实际为 %T", obj)
+	}
+
+	var findings []Finding
+	if sts.Spec.Replicas != nil && *sts.Spec.Replicas > 0 && sts.Status.ReadyReplicas == 0 && sts.Status.ObservedGeneration >= sts.Generation {
+		findings = append(findings, Finding{
+			Severity: "Warning",
+			Reason:   "NoReadyReplicas",
+			Message:  fmt.Sprintf("StatefulSet %s/%s 期望副本数 %d，但就绪副本数为 0", sts.Namespace, sts.Name, *sts.Spec.Replicas),
+		})
+	}
+	if sts.Status.CurrentRevision != "" && sts.Status.UpdateRevision != "" && sts.Status.CurrentRevision != sts.Status.UpdateRevision && sts.Status.UpdatedReplicas < sts.Status.Replicas {
+		findings = append(findings, Finding{
+			Severity: "Warning",
+			Reason:   "RollingUpdateStuck",
+			Message:  fmt.Sprintf("StatefulSet %s/%s 滚动更新停滞在版本 %s -> %s", sts.Namespace, sts.Name, sts.Status.CurrentRevision, sts.Status.UpdateRevision),
+		})
+	}
+
+	return findings, nil
+}
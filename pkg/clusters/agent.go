@@ -0,0 +1,14 @@
+package clusters
+
+import (
+	"k8s.io/client-go/rest"
+
+	diagnosticv1 "github.com/sxslzs/ai-diagnostic-operator/api/v1"
+)
+
+// buildFromAgentEndpoint 将目标集群的 API 请求转发给部署在该集群内、反向代理 kube-apiserver
+// 的 in-cluster agent，适用于目标集群 API Server 没有可直接访问地址的场景
+func (r *Registry) buildFromAgentEndpoint(target *diagnosticv1.ClusterTarget) (*Cluster, error) {
+	restCfg := &rest.Config{Host: target.Spec.AgentEndpoint}
+	return r.restConfigToCluster(restCfg)
+}
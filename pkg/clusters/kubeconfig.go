@@ -0,0 +1,34 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	diagnosticv1 "github.com/sxslzs/ai-diagnostic-operator/api/v1"
+)
+
+// buildFromKubeconfigSecret 从 ClusterTarget.Spec.KubeconfigSecretRef 指向的 Secret 中读取
+// 内联 kubeconfig（数据键 "kubeconfig"）并构建目标集群的客户端
+func (r *Registry) buildFromKubeconfigSecret(ctx context.Context, target *diagnosticv1.ClusterTarget) (*Cluster, error) {
+	var secret corev1.Secret
+	secretKey := client.ObjectKey{Namespace: target.Namespace, Name: target.Spec.KubeconfigSecretRef.Name}
+	if err := r.local.Get(ctx, secretKey, &secret); err != nil {
+		return nil, fmt.Errorf("clusters: 读取 kubeconfig Secret %s 失败: %v", secretKey.Name, err)
+	}
+
+	kubeconfig, ok := secret.Data["kubeconfig"]
+	if !ok {
+		return nil, fmt.Errorf("clusters: Secret %s 缺少 kubeconfig 字段", secretKey.Name)
+	}
+
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("clusters: 解析 kubeconfig 失败: %v", err)
+	}
+
+	return r.restConfigToCluster(restCfg)
+}
@@ -0,0 +1,85 @@
+// Package clusters 提供按 ClusterTarget 名称懒加载、可在关联 Secret 更新后刷新的
+// 多集群客户端注册表，使单个 operator 安装能够跨多个集群监听和诊断故障。
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	diagnosticv1 "github.com/sxslzs/ai-diagnostic-operator/api/v1"
+)
+
+// Cluster 打包了某个受管集群的两套客户端：client-go Clientset（用于 Pod 日志等 REST 子资源），
+// 以及 controller-runtime client.Client（用于该集群上 CRD/通用资源的 Get/List）
+type Cluster struct {
+	Clientset *kubernetes.Clientset
+	Client    client.Client
+}
+
+// Registry 按 "namespace/name" 缓存 ClusterTarget 对应的客户端
+type Registry struct {
+	// local 是管控集群（operator 自身所在集群）的客户端，用于读取 ClusterTarget 及其引用的 Secret
+	local  client.Client
+	scheme *runtime.Scheme
+
+	mu    sync.RWMutex
+	cache map[string]*Cluster
+}
+
+// NewRegistry 构造一个 Registry，scheme 用于构建远程集群的 controller-runtime Client
+func NewRegistry(local client.Client, scheme *runtime.Scheme) *Registry {
+	return &Registry{local: local, scheme: scheme, cache: make(map[string]*Cluster)}
+}
+
+// Get 返回 namespace/name 对应 ClusterTarget 的客户端，首次访问或被 Invalidate 后会重新构建
+func (r *Registry) Get(ctx context.Context, namespace, name string) (*Cluster, error) {
+	key := namespace + "/" + name
+
+	r.mu.RLock()
+	c, ok := r.cache[key]
+	r.mu.RUnlock()
+	if ok {
+		return c, nil
+	}
+
+	var target diagnosticv1.ClusterTarget
+	if err := r.local.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &target); err != nil {
+		return nil, fmt.Errorf("clusters: 获取 ClusterTarget %s/%s 失败: %v", namespace, name, err)
+	}
+
+	cluster, err := r.build(ctx, &target)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cache[key] = cluster
+	r.mu.Unlock()
+	return cluster, nil
+}
+
+// Invalidate 清除 namespace/name 对应的缓存，供 Secret 更新的事件处理器调用，
+// 使下一次 Get 重新读取 ClusterTarget 并构建客户端
+func (r *Registry) Invalidate(namespace, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.cache, namespace+"/"+name)
+}
+
+func (r *Registry) build(ctx context.Context, target *diagnosticv1.ClusterTarget) (*Cluster, error) {
+	switch {
+	case target.Spec.KubeconfigSecretRef != nil:
+		return r.buildFromKubeconfigSecret(ctx, target)
+	case target.Spec.ServiceAccountTokenSecretRef != nil:
+		return r.buildFromServiceAccountToken(ctx, target)
+	case target.Spec.AgentEndpoint != "":
+		return r.buildFromAgentEndpoint(target)
+	default:
+		return nil, fmt.Errorf("clusters: ClusterTarget %s/%s 未配置任何接入方式", target.Namespace, target.Name)
+	}
+}
@@ -0,0 +1,25 @@
+package clusters
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// restConfigToCluster 基于一份 rest.Config 同时构建 client-go Clientset 与 controller-runtime Client，
+// 三种接入方式最终都会转换出一份 rest.Config 后交给本函数统一处理
+func (r *Registry) restConfigToCluster(restCfg *rest.Config) (*Cluster, error) {
+	clientset, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("clusters: 构建 Clientset 失败: %v", err)
+	}
+
+	c, err := client.New(restCfg, client.Options{Scheme: r.scheme})
+	if err != nil {
+		return nil, fmt.Errorf("clusters: 构建 controller-runtime Client 失败: %v", err)
+	}
+
+	return &Cluster{Clientset: clientset, Client: c}, nil
+}
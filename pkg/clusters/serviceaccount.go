@@ -0,0 +1,42 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	diagnosticv1 "github.com/sxslzs/ai-diagnostic-operator/api/v1"
+)
+
+// buildFromServiceAccountToken 使用导入的目标集群 ServiceAccount Token（数据键 "token"，
+// 可选 "ca.crt"）搭配 ClusterTarget.Spec.Server 构建客户端，适用于跨厂商多集群场景下
+// 没有完整 kubeconfig、只能拿到一个长期有效 Token 的情况
+func (r *Registry) buildFromServiceAccountToken(ctx context.Context, target *diagnosticv1.ClusterTarget) (*Cluster, error) {
+	if target.Spec.Server == "" {
+		return nil, fmt.Errorf("clusters: ClusterTarget %s/%s 使用 serviceAccountTokenSecretRef 时必须同时设置 server", target.Namespace, target.Name)
+	}
+
+	var secret corev1.Secret
+	secretKey := client.ObjectKey{Namespace: target.Namespace, Name: target.Spec.ServiceAccountTokenSecretRef.Name}
+	if err := r.local.Get(ctx, secretKey, &secret); err != nil {
+		return nil, fmt.Errorf("clusters: 读取 ServiceAccount Token Secret %s 失败: %v", secretKey.Name, err)
+	}
+
+	token, ok := secret.Data["token"]
+	if !ok {
+		return nil, fmt.Errorf("clusters: Secret %s 缺少 token 字段", secretKey.Name)
+	}
+
+	restCfg := &rest.Config{
+		Host:        target.Spec.Server,
+		BearerToken: string(token),
+	}
+	if ca, ok := secret.Data["ca.crt"]; ok {
+		restCfg.TLSClientConfig.CAData = ca
+	}
+
+	return r.restConfigToCluster(restCfg)
+}
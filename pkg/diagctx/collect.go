@@ -0,0 +1,195 @@
+package diagctx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Collector 聚合 Pod 相关的诊断上下文，Client/Clientset 应指向目标 Pod 实际所在的集群
+// （多集群场景下由 ClusterRegistry 解析得到，而不是一定是 operator 本地集群）
+type Collector struct {
+	Client    client.Client
+	Clientset *kubernetes.Clientset
+}
+
+// Collect 收集 pod 的历史事件、上一次容器日志、资源用量、所在节点状况及 Owner 发布历史。
+// 各子项互不影响：单项采集失败时该项留空、返回部分结果，不中断整体诊断流程；失败原因不会被记录。
+func (c *Collector) Collect(ctx context.Context, pod *corev1.Pod, eventLimit int) *DiagnosisContext {
+	dc := &DiagnosisContext{}
+
+	if events, err := c.collectEvents(ctx, pod, eventLimit); err == nil {
+		dc.Events = events
+	}
+	dc.PreviousLogs = c.collectPreviousLogs(ctx, pod)
+	dc.ResourceUsage = c.collectResourceUsage(ctx, pod)
+	dc.NodeConditions = c.collectNodeConditions(ctx, pod)
+	dc.OwnerRevision = c.collectOwnerRevision(ctx, pod)
+
+	return dc
+}
+
+func (c *Collector) collectEvents(ctx context.Context, pod *corev1.Pod, limit int) ([]EventSummary, error) {
+	events, err := c.Clientset.CoreV1().Events(pod.Namespace).Search(scheme.Scheme, pod)
+	if err != nil {
+		return nil, fmt.Errorf("diagctx: 查询 Pod 事件失败: %v", err)
+	}
+
+	sort.Slice(events.Items, func(i, j int) bool {
+		return events.Items[j].LastTimestamp.Before(&events.Items[i].LastTimestamp)
+	})
+
+	n := len(events.Items)
+	if n > limit {
+		n = limit
+	}
+	summaries := make([]EventSummary, 0, n)
+	for _, e := range events.Items[:n] {
+		summaries = append(summaries, EventSummary{
+			Reason:        e.Reason,
+			Message:       e.Message,
+			Count:         e.Count,
+			LastTimestamp: e.LastTimestamp.Time,
+		})
+	}
+	return summaries, nil
+}
+
+// collectPreviousLogs 对每个重启过的容器拉取 Previous:true 的日志，常用于定位 CrashLoopBackOff
+func (c *Collector) collectPreviousLogs(ctx context.Context, pod *corev1.Pod) string {
+	tailLines := int64(100)
+
+	var b strings.Builder
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.RestartCount == 0 {
+			continue
+		}
+		opts := &corev1.PodLogOptions{Container: cs.Name, Previous: true, TailLines: &tailLines}
+		stream, err := c.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "--- container=%s (previous, restartCount=%d) ---\n", cs.Name, cs.RestartCount)
+		io.Copy(&b, stream)
+		stream.Close()
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// collectResourceUsage 优先选择触发过 OOMKilled 的容器，否则退化为第一个容器
+func (c *Collector) collectResourceUsage(ctx context.Context, pod *corev1.Pod) *ResourceUsage {
+	if len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+
+	container := pod.Spec.Containers[0]
+	var status *corev1.ContainerStatus
+	for i := range pod.Status.ContainerStatuses {
+		cs := &pod.Status.ContainerStatuses[i]
+		if cs.Name == container.Name {
+			status = cs
+		}
+		if cs.LastTerminationState.Terminated != nil && cs.LastTerminationState.Terminated.Reason == "OOMKilled" {
+			status = cs
+			for _, ct := range pod.Spec.Containers {
+				if ct.Name == cs.Name {
+					container = ct
+					break
+				}
+			}
+			break
+		}
+	}
+
+	usage := &ResourceUsage{
+		ContainerName:  container.Name,
+		RequestsCPU:    container.Resources.Requests.Cpu().String(),
+		RequestsMemory: container.Resources.Requests.Memory().String(),
+		LimitsCPU:      container.Resources.Limits.Cpu().String(),
+		LimitsMemory:   container.Resources.Limits.Memory().String(),
+	}
+
+	if pod.Spec.NodeName != "" {
+		var node corev1.Node
+		if err := c.Client.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node); err == nil {
+			usage.NodeAllocatableCPU = node.Status.Allocatable.Cpu().String()
+			usage.NodeAllocatableMemory = node.Status.Allocatable.Memory().String()
+		}
+	}
+
+	if status != nil && status.LastTerminationState.Terminated != nil {
+		term := status.LastTerminationState.Terminated
+		usage.LastTerminatedReason = term.Reason
+		usage.LastTerminatedExitCode = term.ExitCode
+		usage.OOMKilled = term.Reason == "OOMKilled"
+	}
+
+	return usage
+}
+
+func (c *Collector) collectNodeConditions(ctx context.Context, pod *corev1.Pod) []NodeCondition {
+	if pod.Spec.NodeName == "" {
+		return nil
+	}
+	var node corev1.Node
+	if err := c.Client.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node); err != nil {
+		return nil
+	}
+
+	var out []NodeCondition
+	for _, cond := range node.Status.Conditions {
+		switch cond.Type {
+		case corev1.NodeReady, corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure:
+			out = append(out, NodeCondition{Type: string(cond.Type), Status: string(cond.Status), Message: cond.Message})
+		}
+	}
+	return out
+}
+
+// collectOwnerRevision 沿 Pod -> ReplicaSet -> Deployment，或 Pod -> StatefulSet 向上查找发布历史
+func (c *Collector) collectOwnerRevision(ctx context.Context, pod *corev1.Pod) *OwnerRevision {
+	for _, ref := range pod.OwnerReferences {
+		switch ref.Kind {
+		case "ReplicaSet":
+			var rs appsv1.ReplicaSet
+			if err := c.Client.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: ref.Name}, &rs); err != nil {
+				continue
+			}
+			for _, rsRef := range rs.OwnerReferences {
+				if rsRef.Kind != "Deployment" {
+					continue
+				}
+				var dep appsv1.Deployment
+				if err := c.Client.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: rsRef.Name}, &dep); err != nil {
+					continue
+				}
+				return &OwnerRevision{
+					Kind:            "Deployment",
+					Name:            dep.Name,
+					CurrentRevision: dep.Annotations["deployment.kubernetes.io/revision"],
+				}
+			}
+		case "StatefulSet":
+			var sts appsv1.StatefulSet
+			if err := c.Client.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: ref.Name}, &sts); err != nil {
+				continue
+			}
+			return &OwnerRevision{
+				Kind:            "StatefulSet",
+				Name:            sts.Name,
+				CurrentRevision: sts.Status.CurrentRevision,
+				UpdateRevision:  sts.Status.UpdateRevision,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Package diagctx 收集诊断所需的富上下文（历史事件、上一次容器日志、资源配额与
+// 节点状况、所属控制器的发布历史），并将其渲染为可直接拼入 AI Prompt 的分段文本，
+// 弥补仅凭尾部日志和触发原因做诊断时经常出现的"猜测过浅"问题。
+package diagctx
+
+import "time"
+
+// EventSummary 是单条 Pod 相关事件的精简表示
+type EventSummary struct {
+	Reason        string
+	Message       string
+	Count         int32
+	LastTimestamp time.Time
+}
+
+// ResourceUsage 汇总目标容器的资源配额、所在节点的可分配量，以及上一次终止原因
+type ResourceUsage struct {
+	ContainerName          string
+	RequestsCPU            string
+	RequestsMemory         string
+	LimitsCPU              string
+	LimitsMemory           string
+	NodeAllocatableCPU     string
+	NodeAllocatableMemory  string
+	OOMKilled              bool
+	LastTerminatedReason   string
+	LastTerminatedExitCode int32
+}
+
+// NodeCondition 是节点状况的精简表示，仅保留与故障诊断相关的几种
+type NodeCondition struct {
+	Type    string
+	Status  string
+	Message string
+}
+
+// OwnerRevision 记录 Pod 所属控制器（Deployment/StatefulSet）的发布版本信息
+type OwnerRevision struct {
+	Kind            string
+	Name            string
+	CurrentRevision string
+	UpdateRevision  string
+}
+
+// DiagnosisContext 是喂给 AI 的结构化上下文，各字段均可能为空（采集失败或无数据时）
+type DiagnosisContext struct {
+	Events         []EventSummary
+	PreviousLogs   string
+	ResourceUsage  *ResourceUsage
+	NodeConditions []NodeCondition
+	OwnerRevision  *OwnerRevision
+}
@@ -0,0 +1,21 @@
+package diagctx
+
+import "regexp"
+
+// sensitivePatterns 覆盖日志/事件中常见的密钥泄露形式：key=value 风格的凭据字段，以及
+// Authorization: Bearer 头
+var sensitivePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(password|passwd|secret|token|apikey|api_key)\s*[:=]\s*\S+`),
+	regexp.MustCompile(`(?i)Bearer\s+[A-Za-z0-9\-_.]+`),
+}
+
+// Redact 对渲染后的上下文文本做保守脱敏，避免日志/事件中可能出现的密钥原样发往第三方 AI
+// 接口，或随 CR 长期留存在 Status.Context 中；调用方必须在拼入 Prompt 之前调用，而不能只
+// 在落盘前调用
+func Redact(rendered string) string {
+	redacted := rendered
+	for _, pattern := range sensitivePatterns {
+		redacted = pattern.ReplaceAllString(redacted, "[REDACTED]")
+	}
+	return redacted
+}
@@ -0,0 +1,52 @@
+package diagctx
+
+import "testing"
+
+func TestRedactScrubsKeyValueCredentials(t *testing.T) {
+	cases := []string{
+		"password=hunter2",
+		"password: hunter2",
+		"Secret=s3cr3t",
+		"apiKey=abc123",
+		"api_key: abc123",
+		"token=eyJhbGciOiJIUzI1NiIs",
+	}
+	for _, in := range cases {
+		got := Redact(in)
+		if got != "[REDACTED]" {
+			t.Errorf("Redact(%q) = %q, want %q", in, got, "[REDACTED]")
+		}
+	}
+}
+
+func TestRedactScrubsBearerToken(t *testing.T) {
+	in := "Authorization: Bearer abc123.def456-ghi"
+	got := Redact(in)
+	want := "Authorization: [REDACTED]"
+	if got != want {
+		t.Errorf("Redact(%q) = %q, want %q", in, got, want)
+	}
+}
+
+func TestRedactLeavesUnrelatedTextUntouched(t *testing.T) {
+	in := "Pod crashed with exit code 137 (OOMKilled)"
+	if got := Redact(in); got != in {
+		t.Errorf("Redact(%q) = %q, 不应修改不含敏感信息的文本", in, got)
+	}
+}
+
+func TestRedactIsCaseInsensitive(t *testing.T) {
+	in := "PASSWORD=hunter2"
+	if got := Redact(in); got != "[REDACTED]" {
+		t.Errorf("Redact(%q) = %q, want %q", in, got, "[REDACTED]")
+	}
+}
+
+func TestRedactMultipleOccurrences(t *testing.T) {
+	in := "first password=hunter2 then token=xyz789 done"
+	got := Redact(in)
+	want := "first [REDACTED] then [REDACTED] done"
+	if got != want {
+		t.Errorf("Redact(%q) = %q, want %q", in, got, want)
+	}
+}
@@ -0,0 +1,55 @@
+package diagctx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Render 将 DiagnosisContext 渲染为分段的 XML 风格文本块，供 buildPrompt 直接拼入用户消息
+func Render(dc *DiagnosisContext) string {
+	if dc == nil {
+		return ""
+	}
+
+	var b strings.Builder
+
+	if len(dc.Events) > 0 {
+		b.WriteString("<events>\n")
+		for _, e := range dc.Events {
+			fmt.Fprintf(&b, "- [%s] %s (count=%d, last=%s)\n", e.Reason, e.Message, e.Count, e.LastTimestamp.Format("2006-01-02T15:04:05Z07:00"))
+		}
+		b.WriteString("</events>\n")
+	}
+
+	if dc.PreviousLogs != "" {
+		fmt.Fprintf(&b, "<previous_logs>\n%s</previous_logs>\n", dc.PreviousLogs)
+	}
+
+	if u := dc.ResourceUsage; u != nil {
+		b.WriteString("<resources>\n")
+		fmt.Fprintf(&b, "container=%s requests.cpu=%s requests.memory=%s limits.cpu=%s limits.memory=%s\n",
+			u.ContainerName, u.RequestsCPU, u.RequestsMemory, u.LimitsCPU, u.LimitsMemory)
+		if u.NodeAllocatableCPU != "" || u.NodeAllocatableMemory != "" {
+			fmt.Fprintf(&b, "node.allocatable.cpu=%s node.allocatable.memory=%s\n", u.NodeAllocatableCPU, u.NodeAllocatableMemory)
+		}
+		if u.LastTerminatedReason != "" {
+			fmt.Fprintf(&b, "lastTerminated.reason=%s lastTerminated.exitCode=%d oomKilled=%t\n", u.LastTerminatedReason, u.LastTerminatedExitCode, u.OOMKilled)
+		}
+		b.WriteString("</resources>\n")
+	}
+
+	if len(dc.NodeConditions) > 0 {
+		b.WriteString("<node_conditions>\n")
+		for _, cond := range dc.NodeConditions {
+			fmt.Fprintf(&b, "- %s=%s: %s\n", cond.Type, cond.Status, cond.Message)
+		}
+		b.WriteString("</node_conditions>\n")
+	}
+
+	if o := dc.OwnerRevision; o != nil {
+		fmt.Fprintf(&b, "<owner_revision kind=%q name=%q currentRevision=%q updateRevision=%q></owner_revision>\n",
+			o.Kind, o.Name, o.CurrentRevision, o.UpdateRevision)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,109 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const anthropicVersion = "2023-06-01"
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	MaxTokens int                `json:"max_tokens"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int32 `json:"input_tokens"`
+		OutputTokens int32 `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// AnthropicProvider 对接 Anthropic Messages API
+type AnthropicProvider struct {
+	cfg     Config
+	client  *http.Client
+	breaker *CircuitBreaker
+}
+
+// NewAnthropicProvider 构造一个 AnthropicProvider
+func NewAnthropicProvider(cfg Config) *AnthropicProvider {
+	return &AnthropicProvider{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}, breaker: NewCircuitBreaker("anthropic")}
+}
+
+func (p *AnthropicProvider) Complete(ctx context.Context, prompt []Message) (*DiagnosisResult, error) {
+	// Messages API 将 system 提示词拆分到独立字段，而不是放进 messages 数组
+	var system string
+	messages := make([]anthropicMessage, 0, len(prompt))
+	for _, m := range prompt {
+		if m.Role == "system" {
+			system = m.Content
+			continue
+		}
+		messages = append(messages, anthropicMessage{Role: m.Role, Content: m.Content})
+	}
+
+	jsonData, err := json.Marshal(anthropicRequest{
+		Model:     p.cfg.Model,
+		MaxTokens: 1024,
+		System:    system,
+		Messages:  messages,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("llm/anthropic: 序列化请求失败: %v", err)
+	}
+
+	resp, err := doWithRetry(ctx, p.breaker, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", p.cfg.BaseURL+"/v1/messages", bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("x-api-key", p.cfg.APIKey)
+		req.Header.Set("anthropic-version", anthropicVersion)
+		return p.client.Do(req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("llm/anthropic: 调用接口失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "anthropic"); err != nil {
+		return nil, err
+	}
+
+	var apiResp anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("llm/anthropic: 解析响应失败: %v", err)
+	}
+	if len(apiResp.Content) == 0 {
+		return nil, fmt.Errorf("llm/anthropic: 响应内容为空")
+	}
+
+	text := apiResp.Content[0].Text
+	var result DiagnosisResult
+	if err := json.Unmarshal([]byte(text), &result); err != nil {
+		return nil, fmt.Errorf("llm/anthropic: 反序列化 DiagnosisResult 失败, 原始内容: %s, error: %v", text, err)
+	}
+	result.Usage = TokenUsage{
+		PromptTokens:     apiResp.Usage.InputTokens,
+		CompletionTokens: apiResp.Usage.OutputTokens,
+		TotalTokens:      apiResp.Usage.InputTokens + apiResp.Usage.OutputTokens,
+	}
+	return &result, nil
+}
@@ -0,0 +1,80 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultAzureAPIVersion = "2024-02-15-preview"
+
+// AzureOpenAIProvider 对接 Azure OpenAI 的 Chat Completions 部署。
+// 与 OpenAIProvider 的区别在于鉴权头（api-key 而非 Bearer）以及 URL 中携带的部署名/api-version。
+type AzureOpenAIProvider struct {
+	cfg     Config
+	client  *http.Client
+	breaker *CircuitBreaker
+}
+
+// NewAzureOpenAIProvider 构造一个 AzureOpenAIProvider
+func NewAzureOpenAIProvider(cfg Config) *AzureOpenAIProvider {
+	return &AzureOpenAIProvider{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}, breaker: NewCircuitBreaker("azure-openai")}
+}
+
+func (p *AzureOpenAIProvider) Complete(ctx context.Context, prompt []Message) (*DiagnosisResult, error) {
+	jsonData, err := json.Marshal(chatCompletionRequest{
+		Messages:       prompt,
+		Temperature:    0.2,
+		ResponseFormat: &responseFormat{Type: "json_object"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("llm/azure: 序列化请求失败: %v", err)
+	}
+
+	apiVersion := p.cfg.AzureAPIVersion
+	if apiVersion == "" {
+		apiVersion = defaultAzureAPIVersion
+	}
+	url := fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s", p.cfg.BaseURL, p.cfg.Model, apiVersion)
+
+	resp, err := doWithRetry(ctx, p.breaker, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("api-key", p.cfg.APIKey)
+		return p.client.Do(req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("llm/azure: 调用接口失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "azure"); err != nil {
+		return nil, err
+	}
+
+	var apiResp chatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("llm/azure: 解析响应失败: %v", err)
+	}
+	if len(apiResp.Choices) == 0 {
+		return nil, fmt.Errorf("llm/azure: 响应内容为空")
+	}
+
+	content := apiResp.Choices[0].Message.Content
+	var result DiagnosisResult
+	if err := json.Unmarshal([]byte(content), &result); err != nil {
+		return nil, fmt.Errorf("llm/azure: 反序列化 DiagnosisResult 失败, 原始内容: %s, error: %v", content, err)
+	}
+	result.Usage = TokenUsage{
+		PromptTokens:     apiResp.Usage.PromptTokens,
+		CompletionTokens: apiResp.Usage.CompletionTokens,
+		TotalTokens:      apiResp.Usage.TotalTokens,
+	}
+	return &result, nil
+}
@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// circuitBreakerOpenGauge 暴露各后端的熔断状态：1 表示当前处于 Open（拒绝请求），0 表示 Closed（正常）
+var circuitBreakerOpenGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ai_diagnostic_operator_llm_circuit_breaker_open",
+	Help: "AI 后端熔断器状态，1 表示当前处于熔断（Open），0 表示正常（Closed）",
+}, []string{"backend"})
+
+func init() {
+	metrics.Registry.MustRegister(circuitBreakerOpenGauge)
+}
+
+// CircuitBreaker 是一个连续失败计数熔断器：连续失败达到阈值后打开并拒绝后续请求，
+// 冷却时间过后进入半开状态放行探测请求，直到记录下一次成功或失败为止；半开期间不限制放行的请求数。
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	cooldown         time.Duration
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+}
+
+// NewCircuitBreaker 构造一个以 name（后端标识，亦作为 gauge 的 label）命名的熔断器
+func NewCircuitBreaker(name string) *CircuitBreaker {
+	return &CircuitBreaker{name: name, failureThreshold: 5, cooldown: 30 * time.Second}
+}
+
+// Allow 判断当前是否允许发起请求
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return true
+	}
+	return time.Since(b.openedAt) > b.cooldown
+}
+
+// RecordSuccess 记录一次成功调用，重置失败计数并在熔断打开时将其关闭
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	if b.open {
+		b.open = false
+		circuitBreakerOpenGauge.WithLabelValues(b.name).Set(0)
+	}
+}
+
+// RecordFailure 记录一次失败调用，达到阈值时打开（或重新打开）熔断器。
+// 半开状态放行的探测请求失败时也会走到这里：必须无条件刷新 openedAt，
+// 否则冷却时间一旦首次过期，Allow 会永久放行，熔断器对持续中的故障形同虚设。
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures >= b.failureThreshold {
+		b.open = true
+		b.openedAt = time.Now()
+		circuitBreakerOpenGauge.WithLabelValues(b.name).Set(1)
+	}
+}
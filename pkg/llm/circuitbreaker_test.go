@@ -0,0 +1,84 @@
+package llm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerAllowsByDefault(t *testing.T) {
+	b := NewCircuitBreaker("test-default")
+	if !b.Allow() {
+		t.Error("新建的熔断器 Allow() = false, want true")
+	}
+}
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	b := NewCircuitBreaker("test-threshold")
+	for i := 0; i < b.failureThreshold-1; i++ {
+		b.RecordFailure()
+	}
+	if !b.Allow() {
+		t.Fatalf("连续失败 %d 次（未达阈值 %d）后 Allow() = false, want true", b.failureThreshold-1, b.failureThreshold)
+	}
+
+	b.RecordFailure()
+	if b.Allow() {
+		t.Errorf("连续失败达到阈值 %d 后 Allow() = true, want false（熔断器应打开）", b.failureThreshold)
+	}
+}
+
+func TestCircuitBreakerRecordSuccessResetsFailures(t *testing.T) {
+	b := NewCircuitBreaker("test-reset")
+	for i := 0; i < b.failureThreshold-1; i++ {
+		b.RecordFailure()
+	}
+	b.RecordSuccess()
+	b.RecordFailure()
+	if !b.Allow() {
+		t.Error("RecordSuccess 后失败计数应被清零，单次新失败不应重新打开熔断器")
+	}
+}
+
+func TestCircuitBreakerRecordSuccessClosesOpenBreaker(t *testing.T) {
+	b := NewCircuitBreaker("test-close")
+	for i := 0; i < b.failureThreshold; i++ {
+		b.RecordFailure()
+	}
+	if b.Allow() {
+		t.Fatal("前置条件失败：熔断器本应已打开")
+	}
+
+	b.RecordSuccess()
+	if !b.Allow() {
+		t.Error("RecordSuccess 后 Allow() = false, want true（熔断器应被关闭）")
+	}
+}
+
+func TestCircuitBreakerAllowsAfterCooldown(t *testing.T) {
+	b := NewCircuitBreaker("test-cooldown")
+	b.cooldown = 0 // 缩短冷却时间以便测试不必真实等待
+	for i := 0; i < b.failureThreshold; i++ {
+		b.RecordFailure()
+	}
+	if !b.Allow() {
+		t.Error("冷却时间已过期时 Allow() = false, want true（应进入半开状态放行探测请求）")
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbeFailureRefreshesCooldown(t *testing.T) {
+	b := NewCircuitBreaker("test-half-open")
+	b.cooldown = 10 * time.Millisecond
+	for i := 0; i < b.failureThreshold; i++ {
+		b.RecordFailure()
+	}
+	time.Sleep(20 * time.Millisecond)
+	if !b.Allow() {
+		t.Fatal("前置条件失败：冷却时间已过期，半开探测本应被放行")
+	}
+
+	// 半开状态放行的探测请求失败：openedAt 必须被刷新，否则旧的冷却时间已过期会导致 Allow 永久放行
+	b.RecordFailure()
+	if b.Allow() {
+		t.Error("半开探测失败后 Allow() = true, want false（openedAt 应被刷新，重新进入冷却期）")
+	}
+}
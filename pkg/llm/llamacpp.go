@@ -0,0 +1,92 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type llamaCppRequest struct {
+	Prompt      string  `json:"prompt"`
+	Temperature float32 `json:"temperature"`
+	NPredict    int     `json:"n_predict"`
+}
+
+type llamaCppResponse struct {
+	Content         string `json:"content"`
+	TokensEvaluated int32  `json:"tokens_evaluated"`
+	TokensPredicted int32  `json:"tokens_predicted"`
+}
+
+// LlamaCppProvider 对接本地 llama.cpp `server` 暴露的原生 /completion 接口，
+// 该接口只接受单一 prompt 字符串，不支持 messages 数组，需要先手动拼接对话历史。
+type LlamaCppProvider struct {
+	cfg     Config
+	client  *http.Client
+	breaker *CircuitBreaker
+}
+
+// NewLlamaCppProvider 构造一个 LlamaCppProvider
+func NewLlamaCppProvider(cfg Config) *LlamaCppProvider {
+	return &LlamaCppProvider{cfg: cfg, client: &http.Client{Timeout: 60 * time.Second}, breaker: NewCircuitBreaker("llamacpp")}
+}
+
+func (p *LlamaCppProvider) Complete(ctx context.Context, prompt []Message) (*DiagnosisResult, error) {
+	jsonData, err := json.Marshal(llamaCppRequest{
+		Prompt:      flattenPrompt(prompt),
+		Temperature: 0.2,
+		NPredict:    512,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("llm/llamacpp: 序列化请求失败: %v", err)
+	}
+
+	resp, err := doWithRetry(ctx, p.breaker, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", p.cfg.BaseURL+"/completion", bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return p.client.Do(req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("llm/llamacpp: 调用接口失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "llamacpp"); err != nil {
+		return nil, err
+	}
+
+	var apiResp llamaCppResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("llm/llamacpp: 解析响应失败: %v", err)
+	}
+
+	var result DiagnosisResult
+	if err := json.Unmarshal([]byte(apiResp.Content), &result); err != nil {
+		return nil, fmt.Errorf("llm/llamacpp: 反序列化 DiagnosisResult 失败, 原始内容: %s, error: %v", apiResp.Content, err)
+	}
+	result.Usage = TokenUsage{
+		PromptTokens:     apiResp.TokensEvaluated,
+		CompletionTokens: apiResp.TokensPredicted,
+		TotalTokens:      apiResp.TokensEvaluated + apiResp.TokensPredicted,
+	}
+	return &result, nil
+}
+
+// flattenPrompt 将多轮 Message 拼接为 /completion 接口需要的单一 prompt 字符串
+func flattenPrompt(messages []Message) string {
+	var b strings.Builder
+	for _, m := range messages {
+		b.WriteString(strings.ToUpper(m.Role))
+		b.WriteString(": ")
+		b.WriteString(m.Content)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
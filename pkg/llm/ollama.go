@@ -0,0 +1,91 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type ollamaRequest struct {
+	Model    string    `json:"model"`
+	Messages []Message `json:"messages"`
+	Stream   bool      `json:"stream"`
+}
+
+type ollamaResponse struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	// Done 仅在流式响应的最后一个分片上为 true，此时 PromptEvalCount/EvalCount 才会被填充
+	Done            bool  `json:"done"`
+	PromptEvalCount int32 `json:"prompt_eval_count"`
+	EvalCount       int32 `json:"eval_count"`
+}
+
+// OllamaProvider 对接本地或自建的 Ollama 服务 (POST /api/chat)，以 stream=true 发起请求并
+// 增量消费 NDJSON 响应分片，而不是等待服务端一次性攒好非流式响应
+type OllamaProvider struct {
+	cfg     Config
+	client  *http.Client
+	breaker *CircuitBreaker
+}
+
+// NewOllamaProvider 构造一个 OllamaProvider
+func NewOllamaProvider(cfg Config) *OllamaProvider {
+	return &OllamaProvider{cfg: cfg, client: &http.Client{Timeout: 60 * time.Second}, breaker: NewCircuitBreaker("ollama")}
+}
+
+func (p *OllamaProvider) Complete(ctx context.Context, prompt []Message) (*DiagnosisResult, error) {
+	jsonData, err := json.Marshal(ollamaRequest{Model: p.cfg.Model, Messages: prompt, Stream: true})
+	if err != nil {
+		return nil, fmt.Errorf("llm/ollama: 序列化请求失败: %v", err)
+	}
+
+	resp, err := doWithRetry(ctx, p.breaker, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", p.cfg.BaseURL+"/api/chat", bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return p.client.Do(req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("llm/ollama: 调用接口失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "ollama"); err != nil {
+		return nil, err
+	}
+
+	// Ollama 按 NDJSON 分片流式返回 /api/chat 响应，内容需要逐片拼接，
+	// 只有最后一个 done=true 的分片才带上 prompt_eval_count/eval_count
+	var content strings.Builder
+	var usage ollamaResponse
+	decoder := json.NewDecoder(resp.Body)
+	for decoder.More() {
+		var chunk ollamaResponse
+		if err := decoder.Decode(&chunk); err != nil {
+			return nil, fmt.Errorf("llm/ollama: 解析流式响应分片失败: %v", err)
+		}
+		content.WriteString(chunk.Message.Content)
+		if chunk.Done {
+			usage = chunk
+		}
+	}
+
+	var result DiagnosisResult
+	if err := json.Unmarshal([]byte(content.String()), &result); err != nil {
+		return nil, fmt.Errorf("llm/ollama: 反序列化 DiagnosisResult 失败, 原始内容: %s, error: %v", content.String(), err)
+	}
+	result.Usage = TokenUsage{
+		PromptTokens:     usage.PromptEvalCount,
+		CompletionTokens: usage.EvalCount,
+		TotalTokens:      usage.PromptEvalCount + usage.EvalCount,
+	}
+	return &result, nil
+}
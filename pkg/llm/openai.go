@@ -0,0 +1,96 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type chatCompletionRequest struct {
+	Model          string          `json:"model"`
+	Messages       []Message       `json:"messages"`
+	Temperature    float32         `json:"temperature"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+}
+
+type responseFormat struct {
+	Type string `json:"type"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int32 `json:"prompt_tokens"`
+		CompletionTokens int32 `json:"completion_tokens"`
+		TotalTokens      int32 `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// OpenAIProvider 对接 OpenAI 及兼容其 Chat Completions 协议的服务
+type OpenAIProvider struct {
+	cfg     Config
+	client  *http.Client
+	breaker *CircuitBreaker
+}
+
+// NewOpenAIProvider 构造一个 OpenAIProvider
+func NewOpenAIProvider(cfg Config) *OpenAIProvider {
+	return &OpenAIProvider{cfg: cfg, client: &http.Client{Timeout: 30 * time.Second}, breaker: NewCircuitBreaker("openai")}
+}
+
+func (p *OpenAIProvider) Complete(ctx context.Context, prompt []Message) (*DiagnosisResult, error) {
+	jsonData, err := json.Marshal(chatCompletionRequest{
+		Model:          p.cfg.Model,
+		Messages:       prompt,
+		Temperature:    0.2,
+		ResponseFormat: &responseFormat{Type: "json_object"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("llm/openai: 序列化请求失败: %v", err)
+	}
+
+	resp, err := doWithRetry(ctx, p.breaker, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", p.cfg.BaseURL+"/chat/completions", bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+p.cfg.APIKey)
+		return p.client.Do(req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("llm/openai: 调用接口失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, "openai"); err != nil {
+		return nil, err
+	}
+
+	var apiResp chatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("llm/openai: 解析响应失败: %v", err)
+	}
+	if len(apiResp.Choices) == 0 {
+		return nil, fmt.Errorf("llm/openai: 响应内容为空")
+	}
+
+	content := apiResp.Choices[0].Message.Content
+	var result DiagnosisResult
+	if err := json.Unmarshal([]byte(content), &result); err != nil {
+		return nil, fmt.Errorf("llm/openai: 反序列化 DiagnosisResult 失败, 原始内容: %s, error: %v", content, err)
+	}
+	result.Usage = TokenUsage{
+		PromptTokens:     apiResp.Usage.PromptTokens,
+		CompletionTokens: apiResp.Usage.CompletionTokens,
+		TotalTokens:      apiResp.Usage.TotalTokens,
+	}
+	return &result, nil
+}
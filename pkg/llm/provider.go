@@ -0,0 +1,82 @@
+// Package llm 定义了可插拔的大模型后端接入层。PodDiagnosisReconciler 不再直接
+// 调用某个固定的 Chat Completions 接口，而是面向 Provider 接口编程，具体使用
+// 哪个后端由 AIBackend 资源在运行时决定，便于在不重启 operator 的前提下切换模型供应商。
+package llm
+
+import (
+	"context"
+	"fmt"
+)
+
+// Message 对应一轮对话中的一条消息
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// TokenUsage 记录一次补全调用消耗的 Token 数，用于回写 PodDiagnosisStatus 供成本审计
+type TokenUsage struct {
+	PromptTokens     int32
+	CompletionTokens int32
+	TotalTokens      int32
+}
+
+// RemediationAction 是 AI 在诊断结果中建议的一项可执行修复动作，由
+// remediation_controller 在通过策略与审批校验后负责落地执行
+type RemediationAction struct {
+	// Kind 动作类型：RestartPod、IncreaseMemoryLimit、PatchImage、DeletePVC、CordonNode
+	Kind string `json:"kind"`
+	// Params 执行该动作所需的补充参数，例如 IncreaseMemoryLimit 的目标内存值、
+	// PatchImage 的目标镜像
+	Params map[string]string `json:"params,omitempty"`
+	// Reason 该动作针对的问题简述，便于审批人判断是否批准
+	Reason string `json:"reason,omitempty"`
+}
+
+// DiagnosisResult 是所有后端统一返回的诊断结果
+type DiagnosisResult struct {
+	RootCause  string `json:"rootCause"`
+	Suggestion string `json:"suggestion"`
+	// Actions 是 AI 建议的自动修复动作列表，留空表示本次诊断不建议自动修复
+	Actions []RemediationAction `json:"actions,omitempty"`
+	// Usage 不参与 AI 返回内容的 JSON 解析，由各 Provider 从响应的 usage 字段单独填充
+	Usage TokenUsage `json:"-"`
+}
+
+// Provider 是各 LLM 后端需要实现的统一接口
+type Provider interface {
+	// Complete 向后端发起一次补全请求，prompt 为完整的对话历史
+	Complete(ctx context.Context, prompt []Message) (*DiagnosisResult, error)
+}
+
+// Config 描述了构建某个 Provider 所需的连接信息，来自 AIBackend 资源及其关联的 Secret
+type Config struct {
+	// Backend 后端类型：openai、azure-openai、anthropic、ollama、llamacpp
+	Backend string
+	// BaseURL 服务地址
+	BaseURL string
+	// APIKey 鉴权凭据，本地后端（ollama/llamacpp）通常为空
+	APIKey string
+	// Model 模型名称或 Azure 部署名称
+	Model string
+	// AzureAPIVersion 仅 Backend 为 azure-openai 时使用
+	AzureAPIVersion string
+}
+
+// NewProvider 按 Config.Backend 构建对应的 Provider 实现
+func NewProvider(cfg Config) (Provider, error) {
+	switch cfg.Backend {
+	case "", "openai":
+		return NewOpenAIProvider(cfg), nil
+	case "azure-openai":
+		return NewAzureOpenAIProvider(cfg), nil
+	case "anthropic":
+		return NewAnthropicProvider(cfg), nil
+	case "ollama":
+		return NewOllamaProvider(cfg), nil
+	case "llamacpp":
+		return NewLlamaCppProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("llm: 不支持的后端类型 %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,80 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// retryPolicy 控制对 429/5xx 响应的指数退避重试参数
+type retryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{MaxRetries: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 10 * time.Second}
+
+// doWithRetry 在熔断器放行的前提下执行 do，对 429/5xx 响应以及 do 返回的错误（包括连接失败）
+// 按指数退避加抖动重试，其余状态码的响应直接返回。最终结果会同步给熔断器以更新其失败计数。
+func doWithRetry(ctx context.Context, breaker *CircuitBreaker, do func() (*http.Response, error)) (*http.Response, error) {
+	if !breaker.Allow() {
+		return nil, fmt.Errorf("llm: 熔断器已打开，暂时拒绝对 %s 的请求", breaker.name)
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt <= defaultRetryPolicy.MaxRetries; attempt++ {
+		resp, err = do()
+		if err == nil && !shouldRetryStatus(resp.StatusCode) {
+			breaker.RecordSuccess()
+			return resp, nil
+		}
+		if err == nil {
+			resp.Body.Close()
+		}
+		if attempt == defaultRetryPolicy.MaxRetries {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoffDelay(attempt)):
+		}
+	}
+
+	breaker.RecordFailure()
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("llm: 重试 %d 次后仍返回非预期状态码 %d", defaultRetryPolicy.MaxRetries, resp.StatusCode)
+}
+
+func shouldRetryStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// checkStatus 在响应非 2xx 时读取响应体作为错误信息返回。doWithRetry 只对 429/5xx 重试，
+// 其余非 2xx（400/401/403/404 等，常见于 baseURL/apiKey 配置错误）会被直接放行到这里，
+// 必须在 json.Unmarshal 之前拦截，否则会被误报为"反序列化失败"而掩盖真正的 HTTP 错误
+func checkStatus(resp *http.Response, provider string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("llm/%s: 接口返回非 2xx 状态码 %d: %s", provider, resp.StatusCode, body)
+}
+
+// backoffDelay 计算第 attempt 次重试前的等待时间：取 d = 2^attempt * BaseDelay（封顶 MaxDelay），
+// 在 [d/2, d) 区间内随机抖动
+func backoffDelay(attempt int) time.Duration {
+	delay := defaultRetryPolicy.BaseDelay * time.Duration(1<<uint(attempt))
+	if delay > defaultRetryPolicy.MaxDelay {
+		delay = defaultRetryPolicy.MaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)))
+	return delay/2 + jitter/2
+}
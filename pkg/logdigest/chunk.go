@@ -0,0 +1,40 @@
+package logdigest
+
+import "regexp"
+
+// recordStartPattern 匹配一条新逻辑记录的起始行：ISO 日期前缀、常见日志级别 Token，
+// 或 Java 异常的堆栈根（"Caused by:"/"Exception in thread"/"XxxException:"）
+var recordStartPattern = regexp.MustCompile(
+	`^(\d{4}-\d{2}-\d{2}|\[?(TRACE|DEBUG|INFO|WARN|WARNING|ERROR|FATAL)\]?\b|Caused by:|Exception in thread|[A-Za-z0-9.]+Exception:)`,
+)
+
+// errorOrFatalPattern 用于标记命中错误/致命级别的记录，供 chunkRecords 与 lastErrorContextWindow 使用
+var errorOrFatalPattern = regexp.MustCompile(`(?i)\b(error|fatal|panic|exception)\b`)
+
+// chunkRecords 把原始日志按行扫描，在命中 recordStartPattern 的行开启新记录，
+// 其余行视为上一条记录的延续（典型情形是多行堆栈跟踪）
+func chunkRecords(lines []string) []Record {
+	var records []Record
+	var current *Record
+
+	for _, line := range lines {
+		if recordStartPattern.MatchString(line) || current == nil {
+			if current != nil {
+				records = append(records, *current)
+			}
+			current = &Record{Lines: []string{line}}
+		} else {
+			current.Lines = append(current.Lines, line)
+		}
+		if errorOrFatalPattern.MatchString(line) {
+			current.IsErrorOrFatal = true
+		}
+	}
+	if current != nil {
+		records = append(records, *current)
+	}
+	for i := range records {
+		records[i].Count = 1
+	}
+	return records
+}
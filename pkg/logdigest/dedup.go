@@ -0,0 +1,76 @@
+package logdigest
+
+import "strings"
+
+// shingleWidth 是 shingle 的词窗口大小（5-gram）
+const shingleWidth = 5
+
+// dedupJaccardThreshold 达到或超过该相似度即视为近似重复并折叠
+const dedupJaccardThreshold = 0.85
+
+// shingles 把记录文本切词后按 shingleWidth 取滑动窗口生成 shingle 集合；
+// 记录行数较少，直接按集合精确计算 Jaccard 即可，不需要 MinHash 的近似签名
+func shingles(text string) map[string]struct{} {
+	words := strings.Fields(text)
+	set := make(map[string]struct{})
+	if len(words) < shingleWidth {
+		set[strings.Join(words, " ")] = struct{}{}
+		return set
+	}
+	for i := 0; i+shingleWidth <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleWidth], " ")] = struct{}{}
+	}
+	return set
+}
+
+// jaccard 计算两个 shingle 集合的 Jaccard 相似度
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	intersection := 0
+	for s := range a {
+		if _, ok := b[s]; ok {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	if union == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(union)
+}
+
+// dedupeRecords 按出现顺序折叠近似重复的记录：新记录与已保留的某条记录相似度达到
+// dedupJaccardThreshold 时，原记录的 Count 递增并把 LastLines 更新为本次出现的内容；
+// 否则作为一条新的唯一记录保留
+func dedupeRecords(records []Record) []Record {
+	var kept []Record
+	var keptShingles []map[string]struct{}
+
+	for _, rec := range records {
+		// 未设置 Count 的记录按出现一次计
+		if rec.Count == 0 {
+			rec.Count = 1
+		}
+		sh := shingles(joinLines(rec.Lines))
+		matched := -1
+		for i, ks := range keptShingles {
+			if jaccard(sh, ks) >= dedupJaccardThreshold {
+				matched = i
+				break
+			}
+		}
+		if matched == -1 {
+			kept = append(kept, rec)
+			keptShingles = append(keptShingles, sh)
+			continue
+		}
+		kept[matched].Count++
+		kept[matched].LastLines = rec.Lines
+		if rec.IsErrorOrFatal {
+			kept[matched].IsErrorOrFatal = true
+		}
+	}
+	return kept
+}
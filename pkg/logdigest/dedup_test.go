@@ -0,0 +1,71 @@
+package logdigest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// longLineWithSuffix 生成一条足够长的日志行，使得仅最后一个 token 不同时，
+// shingle 集合的 Jaccard 相似度仍落在 dedupJaccardThreshold 之上（验证 dedupeRecords
+// 是“近似”而非“完全相同”去重）
+func longLineWithSuffix(suffix string) string {
+	var words []string
+	for i := 0; i < 60; i++ {
+		words = append(words, fmt.Sprintf("word%d", i))
+	}
+	words = append(words, suffix)
+	for i := 0; i < 5; i++ {
+		words = append(words, fmt.Sprintf("tail%d", i))
+	}
+	return strings.Join(words, " ")
+}
+
+func TestDedupeRecordsCollapsesSimilarRecords(t *testing.T) {
+	lineA := longLineWithSuffix("DIFFERENT_A")
+	lineB := longLineWithSuffix("DIFFERENT_B")
+	records := []Record{
+		{Lines: []string{lineA}},
+		{Lines: []string{lineB}},
+		{Lines: []string{"2024-01-01 INFO server started on port 8080"}},
+	}
+
+	got := dedupeRecords(records)
+
+	if len(got) != 2 {
+		t.Fatalf("dedupeRecords() 返回 %d 条记录, want 2: %+v", len(got), got)
+	}
+	if got[0].Count != 2 {
+		t.Errorf("第一条记录 Count = %d, want 2", got[0].Count)
+	}
+	if len(got[0].LastLines) == 0 || got[0].LastLines[0] != lineB {
+		t.Errorf("第一条记录 LastLines = %v, want 更新为最后一次出现的内容 %q", got[0].LastLines, lineB)
+	}
+	if got[1].Count != 1 {
+		t.Errorf("第二条记录 Count = %d, want 1", got[1].Count)
+	}
+}
+
+func TestDedupeRecordsKeepsIsErrorOrFatal(t *testing.T) {
+	lineA := longLineWithSuffix("DIFFERENT_A")
+	lineB := longLineWithSuffix("DIFFERENT_B")
+	records := []Record{
+		{Lines: []string{lineA}, IsErrorOrFatal: false},
+		{Lines: []string{lineB}, IsErrorOrFatal: true},
+	}
+
+	got := dedupeRecords(records)
+
+	if len(got) != 1 {
+		t.Fatalf("dedupeRecords() 返回 %d 条记录, want 1: %+v", len(got), got)
+	}
+	if !got[0].IsErrorOrFatal {
+		t.Errorf("折叠后的记录 IsErrorOrFatal = false, 本应在任一重复项命中时保持 true")
+	}
+}
+
+func TestDedupeRecordsEmptyInput(t *testing.T) {
+	if got := dedupeRecords(nil); len(got) != 0 {
+		t.Errorf("dedupeRecords(nil) = %+v, want 空切片", got)
+	}
+}
@@ -0,0 +1,156 @@
+package logdigest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// errorContextWindow 命中最后一条错误/致命记录时，向上下各保留的原始行数
+const errorContextWindow = 20
+
+// approxCharsPerToken 用于把 MaxTokens 折算为字符数的粗略估算（不追求精确分词）
+const approxCharsPerToken = 4
+
+// Digest 对原始日志执行切分、近似去重、（可选）基于 Embedding 的 Top-K 检索，
+// 并在最终文本中保留最后一条错误/致命记录的上下文窗口与日志尾部字节（设置了 MaxTokens 时
+// 二者同样受预算约束，见 composeWithBudget），
+// 产出可直接拼入 AI Prompt 的摘要文本及统计信息
+func Digest(ctx context.Context, logs string, opts Options) (*Result, error) {
+	rawBytes := len(logs)
+	lines := strings.Split(logs, "\n")
+
+	records := chunkRecords(lines)
+	unique := dedupeRecords(records)
+
+	selected := unique
+	if opts.EmbeddingProvider != nil && opts.TriggerReason != "" {
+		k := topKFromMaxTokens(opts.MaxTokens, unique)
+		if picked, err := selectTopK(ctx, opts.EmbeddingProvider, unique, opts.TriggerReason, k); err == nil {
+			selected = picked
+		}
+		// Embedding 检索失败时直接回退为全部去重记录，不让检索失败影响主诊断流程
+	}
+
+	var body strings.Builder
+	for i, rec := range selected {
+		if i > 0 {
+			body.WriteString("\n")
+		}
+		body.WriteString(rec.Text())
+		body.WriteString("\n")
+	}
+
+	var errBlock strings.Builder
+	if errWindow := lastErrorContextWindow(lines); errWindow != "" {
+		fmt.Fprintf(&errBlock, "\n--- 最后一条错误/致命记录上下文 (前后各 %d 行) ---\n%s\n", errorContextWindow, errWindow)
+	}
+
+	var tailBlock strings.Builder
+	if opts.KeepTailBytes > 0 {
+		tail := truncateTailSafe(logs, opts.KeepTailBytes)
+		fmt.Fprintf(&tailBlock, "\n--- 日志尾部原文 (%d 字节) ---\n%s\n", opts.KeepTailBytes, tail)
+	}
+
+	text := composeWithBudget(body.String(), errBlock.String(), tailBlock.String(), opts.MaxTokens)
+
+	return &Result{
+		Text: text,
+		Stats: Stats{
+			RawBytes:      int32(rawBytes),
+			DigestedBytes: int32(len(text)),
+			UniqueRecords: int32(len(unique)),
+		},
+	}, nil
+}
+
+// lastErrorContextWindow 在原始行中定位最后一条命中错误/致命关键字的行，
+// 返回其前后 errorContextWindow 行组成的原文窗口；未命中时返回空串
+func lastErrorContextWindow(lines []string) string {
+	lastIdx := -1
+	for i, line := range lines {
+		if errorOrFatalPattern.MatchString(line) {
+			lastIdx = i
+		}
+	}
+	if lastIdx == -1 {
+		return ""
+	}
+	start := lastIdx - errorContextWindow
+	if start < 0 {
+		start = 0
+	}
+	end := lastIdx + errorContextWindow + 1
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return strings.Join(lines[start:end], "\n")
+}
+
+// topKFromMaxTokens 按 MaxTokens 粗略折算出 Embedding 检索应挑选的记录条数；
+// 未设置 MaxTokens 时退化为保留全部记录（即不做 Top-K 过滤）
+func topKFromMaxTokens(maxTokens int, records []Record) int {
+	if maxTokens <= 0 {
+		return len(records)
+	}
+	avgLen := 1
+	if len(records) > 0 {
+		total := 0
+		for _, r := range records {
+			total += len(r.Text())
+		}
+		avgLen = total / len(records)
+		if avgLen == 0 {
+			avgLen = 1
+		}
+	}
+	budget := maxTokens * approxCharsPerToken
+	k := budget / avgLen
+	if k < 1 {
+		k = 1
+	}
+	return k
+}
+
+// composeWithBudget 在 MaxTokens 折算出的字符预算内拼接 body/errBlock/tailBlock 三段文本。
+// 错误上下文块（最后一条错误/致命记录 ±errorContextWindow 行）是强制保留项，优先从预算中
+// 为其保留空间；其余预算按 tailBlock 优先（对应用户显式配置的 KeepTailBytes）、body 其次的
+// 顺序分配，避免像之前那样对整段拼接文本做一次性尾部截断，导致预算较小、KeepTailBytes 较大时
+// 错误上下文块被整体挤出结果之外
+func composeWithBudget(body, errBlock, tailBlock string, maxTokens int) string {
+	if maxTokens <= 0 {
+		return body + errBlock + tailBlock
+	}
+
+	budget := maxTokens * approxCharsPerToken
+
+	reserved := errBlock
+	if len(reserved) > budget {
+		reserved = truncateTailSafe(reserved, budget)
+	}
+	remaining := budget - len(reserved)
+
+	tail := truncateTailSafe(tailBlock, remaining)
+	remaining -= len(tail)
+
+	head := truncateTailSafe(body, remaining)
+
+	return head + reserved + tail
+}
+
+// truncateTailSafe 保留字符串末尾最多 maxBytes 字节，并向后跳过可能被截断的多字节 UTF-8 前缀，
+// 避免把日志中的非 ASCII 字符（如中文异常信息）从中间切断产生乱码
+func truncateTailSafe(s string, maxBytes int) string {
+	if maxBytes <= 0 {
+		return ""
+	}
+	if len(s) <= maxBytes {
+		return s
+	}
+	cut := len(s) - maxBytes
+	for cut < len(s) && !utf8.RuneStart(s[cut]) {
+		cut++
+	}
+	return s[cut:]
+}
@@ -0,0 +1,61 @@
+package logdigest
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestComposeWithBudgetNoLimit(t *testing.T) {
+	got := composeWithBudget("body", "err", "tail", 0)
+	want := "bodyerrtail"
+	if got != want {
+		t.Errorf("composeWithBudget() = %q, want %q", got, want)
+	}
+}
+
+func TestComposeWithBudgetReservesErrBlock(t *testing.T) {
+	errBlock := strings.Repeat("e", 20)
+	body := strings.Repeat("b", 100)
+	tail := strings.Repeat("t", 100)
+
+	// maxTokens*approxCharsPerToken 刚好等于 errBlock 长度，body/tail 应被挤占到只剩 errBlock
+	got := composeWithBudget(body, errBlock, tail, len(errBlock)/approxCharsPerToken)
+
+	if !strings.Contains(got, errBlock) {
+		t.Errorf("composeWithBudget() = %q, 未包含强制保留的错误上下文块 %q", got, errBlock)
+	}
+}
+
+func TestComposeWithBudgetPrefersTailOverBody(t *testing.T) {
+	body := strings.Repeat("b", 100)
+	tail := strings.Repeat("t", 20)
+
+	// 预算仅够容纳 tailBlock，body 应被完全挤出
+	got := composeWithBudget(body, "", tail, len(tail)/approxCharsPerToken)
+
+	if !strings.Contains(got, tail) {
+		t.Errorf("composeWithBudget() = %q, 未优先保留 tailBlock %q", got, tail)
+	}
+	if strings.Contains(got, body) {
+		t.Errorf("composeWithBudget() = %q, body 本应在预算不足时被挤出", got)
+	}
+}
+
+func TestTruncateTailSafeKeepsTail(t *testing.T) {
+	got := truncateTailSafe("abcdefgh", 4)
+	if got != "efgh" {
+		t.Errorf("truncateTailSafe() = %q, want %q", got, "efgh")
+	}
+}
+
+func TestTruncateTailSafeDoesNotSplitMultiByteRune(t *testing.T) {
+	s := "a中文b"
+	// 从任意字节边界截断都不应产生乱码：结果必须是合法 UTF-8 且不多于原字符串
+	for n := 0; n <= len(s); n++ {
+		got := truncateTailSafe(s, n)
+		if !utf8.ValidString(got) {
+			t.Errorf("truncateTailSafe(%q, %d) = %q, 不是合法的 UTF-8", s, n, got)
+		}
+	}
+}
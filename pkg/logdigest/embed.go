@@ -0,0 +1,141 @@
+package logdigest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+)
+
+// EmbeddingProvider 为日志记录与触发原因计算向量表示，用于挑选与故障最相关的记录
+type EmbeddingProvider interface {
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// HTTPEmbeddingProvider 对接兼容 OpenAI Embeddings 接口的服务：
+// POST {BaseURL}/embeddings {"model": Model, "input": [...]}
+type HTTPEmbeddingProvider struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+	client  *http.Client
+}
+
+// NewHTTPEmbeddingProvider 构造一个 HTTPEmbeddingProvider
+func NewHTTPEmbeddingProvider(baseURL, apiKey, model string) *HTTPEmbeddingProvider {
+	return &HTTPEmbeddingProvider{BaseURL: baseURL, APIKey: apiKey, Model: model, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+type embeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (p *HTTPEmbeddingProvider) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	payload, err := json.Marshal(embeddingRequest{Model: p.Model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("logdigest: 序列化 Embedding 请求失败: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", p.BaseURL+"/embeddings", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("logdigest: 调用 Embedding 接口失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("logdigest: Embedding 接口返回非 200 状态码 %d: %s", resp.StatusCode, body)
+	}
+
+	var apiResp embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("logdigest: 解析 Embedding 响应失败: %v", err)
+	}
+	vectors := make([][]float64, 0, len(apiResp.Data))
+	for _, d := range apiResp.Data {
+		vectors = append(vectors, d.Embedding)
+	}
+	return vectors, nil
+}
+
+// cosineSimilarity 计算两个向量的余弦相似度
+func cosineSimilarity(a, b []float64) float64 {
+	var dot, normA, normB float64
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// selectTopK 用 EmbeddingProvider 为每条记录及 query 计算向量，按余弦相似度降序取前 k 条记录，
+// 原始顺序不保留；任何一步失败都直接返回错误，由调用方回退为保留全部记录
+func selectTopK(ctx context.Context, provider EmbeddingProvider, records []Record, query string, k int) ([]Record, error) {
+	if k <= 0 || k >= len(records) {
+		return records, nil
+	}
+
+	texts := make([]string, 0, len(records)+1)
+	texts = append(texts, query)
+	for _, rec := range records {
+		texts = append(texts, rec.Text())
+	}
+
+	vectors, err := provider.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("logdigest: Embedding 返回的向量数量(%d)与输入文本数量(%d)不一致", len(vectors), len(texts))
+	}
+
+	queryVec := vectors[0]
+	type scored struct {
+		rec   Record
+		score float64
+	}
+	scoredRecords := make([]scored, len(records))
+	for i, rec := range records {
+		scoredRecords[i] = scored{rec: rec, score: cosineSimilarity(queryVec, vectors[i+1])}
+	}
+
+	for i := 0; i < len(scoredRecords); i++ {
+		for j := i + 1; j < len(scoredRecords); j++ {
+			if scoredRecords[j].score > scoredRecords[i].score {
+				scoredRecords[i], scoredRecords[j] = scoredRecords[j], scoredRecords[i]
+			}
+		}
+	}
+
+	out := make([]Record, 0, k)
+	for i := 0; i < k && i < len(scoredRecords); i++ {
+		out = append(out, scoredRecords[i].rec)
+	}
+	return out, nil
+}
@@ -0,0 +1,69 @@
+// Package logdigest 在 getPodLogs 抓取到原始日志与构造 AI Prompt 之间插入一道预处理流水线：
+// 把日志切分为逻辑记录、折叠近似重复的记录、并在可选配置了 Embedding 接口时按与触发原因的
+// 相似度挑选最相关的记录，从而避免成千上万行重复的堆栈跟踪把模型上下文塞满、拖累诊断质量。
+package logdigest
+
+import "fmt"
+
+// Record 是一条切分后的逻辑日志记录（可能跨多行，例如一段 Java 堆栈）
+type Record struct {
+	// Lines 是该记录首次出现时的原始行
+	Lines []string
+	// LastLines 在该记录被判定为近似重复时，记录最后一次出现的原始行；Count==1 时为空
+	LastLines []string
+	// Count 折叠近似重复记录后，该记录（含其近似变体）出现的总次数
+	Count int
+	// IsErrorOrFatal 标记该记录是否命中错误/致命级别的关键字
+	IsErrorOrFatal bool
+}
+
+// Text 还原该记录供拼入 Prompt 的文本；出现过近似重复时，同时展示首次与最后一次的内容
+func (r Record) Text() string {
+	out := joinLines(r.Lines)
+	if r.Count > 1 {
+		out += fmt.Sprintf("\n... (以上记录近似重复出现 %d 次，最后一次如下) ...\n", r.Count)
+		out += joinLines(r.LastLines)
+	}
+	return out
+}
+
+func joinLines(lines []string) string {
+	out := ""
+	for i, line := range lines {
+		if i > 0 {
+			out += "\n"
+		}
+		out += line
+	}
+	return out
+}
+
+// Options 控制预处理流水线的行为，字段与 PodDiagnosisSpec.LogDigest 一一对应
+type Options struct {
+	// MaxTokens 粗略限制最终喂给 AI 的 Token 数量（按 4 字符约等于 1 Token 估算），<=0 表示不限制
+	MaxTokens int
+	// KeepTailBytes 保留原始日志末尾的这么多字节，拼接在摘要之后，
+	// 避免预处理把最新、最贴近当前故障的内容裁剪掉；设置了 MaxTokens 时，尾部只能占用
+	// 错误上下文块之后剩余的预算，超出部分会被截掉
+	KeepTailBytes int
+	// EmbeddingModel 非空时启用基于 Embedding 的 Top-K 检索，具体含义由 EmbeddingProvider 决定
+	EmbeddingModel string
+	// EmbeddingProvider 可选，提供时才会执行基于相似度的检索；为 nil 时回退为保留全部去重后的记录
+	EmbeddingProvider EmbeddingProvider
+	// TriggerReason 作为 Embedding 检索的查询文本
+	TriggerReason string
+}
+
+// Stats 记录一次预处理流水线的处理情况，回写到 PodDiagnosisStatus.LogStats 供可观测性使用
+type Stats struct {
+	RawBytes      int32
+	DigestedBytes int32
+	UniqueRecords int32
+}
+
+// Result 是预处理流水线的最终产出
+type Result struct {
+	// Text 是拼接好、可直接塞进 AI Prompt 的摘要文本
+	Text  string
+	Stats Stats
+}